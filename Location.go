@@ -11,6 +11,11 @@ import (
 type Location struct {
 	ChannelId string   // 网关中的channelId
 	GateId string      // 网关ID
+	Device string      // 登录的设备
+	LoginAt int64      // 登录时间 毫秒
+	ExpireAt int64     // 这个设备的过期时间 毫秒，0表示跟随账号的过期时间
+	App string         // 登录的app
+	Account string     // 所属的账号，读取时由SessionStorage填充，不参与编码
 }
 func (loc *Location) Bytes() []byte {
 	if loc == nil {
@@ -19,6 +24,10 @@ func (loc *Location) Bytes() []byte {
 	buf := new(bytes.Buffer)
 	_= endian.WriteShortBytes(buf, []byte(loc.ChannelId))
 	_ = endian.WriteShortBytes(buf, []byte(loc.GateId))
+	_ = endian.WriteShortBytes(buf, []byte(loc.Device))
+	_ = endian.WriteUint64(buf, uint64(loc.LoginAt))
+	_ = endian.WriteUint64(buf, uint64(loc.ExpireAt))
+	_ = endian.WriteShortBytes(buf, []byte(loc.App))
 	return buf.Bytes()
 }
 func (loc *Location) Unmarshal(data []byte) (err error) {
@@ -34,5 +43,37 @@ func (loc *Location) Unmarshal(data []byte) (err error) {
 	if err != nil {
 		return
 	}
+	// 兼容旧版本只有ChannelId和GateId的数据
+	if buf.Len() == 0 {
+		return
+	}
+	loc.Device, err = endian.ReadShortString(buf)
+	if err != nil {
+		return
+	}
+	loginAt, err := endian.ReadUint64(buf)
+	if err != nil {
+		return
+	}
+	loc.LoginAt = int64(loginAt)
+	// 兼容没有过期时间的数据
+	if buf.Len() == 0 {
+		return
+	}
+	expireAt, err := endian.ReadUint64(buf)
+	if err != nil {
+		return
+	}
+	loc.ExpireAt = int64(expireAt)
+	// 兼容没有app的数据
+	if buf.Len() == 0 {
+		return
+	}
+	loc.App, err = endian.ReadShortString(buf)
 	return
-}
\ No newline at end of file
+}
+
+// Expired 设备的位置信息在now（毫秒）时是否已经过期
+func (loc *Location) Expired(now int64) bool {
+	return loc.ExpireAt > 0 && loc.ExpireAt <= now
+}
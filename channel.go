@@ -7,6 +7,31 @@ import (
 	"time"
 )
 
+// ErrChannelBusy 写队列已满，消息没有被放入队列
+var ErrChannelBusy = errors.New("err:channel busy")
+
+// OverflowPolicy 写队列满了之后的处理策略
+type OverflowPolicy string
+
+const (
+	// OverflowDropNewest 丢弃本次Push的消息
+	OverflowDropNewest OverflowPolicy = "drop_newest"
+	// OverflowDropOldest 丢弃队列中最早的消息，放入本次Push的消息
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowClose 关闭这个消费太慢的Channel
+	OverflowClose OverflowPolicy = "close"
+)
+
+// ChannelOptions Channel 写队列的配置
+type ChannelOptions struct {
+	// WriteQueueSize 写队列的长度
+	WriteQueueSize int
+	// PushTimeout 队列满时Push等待的最长时间，0表示不等待
+	PushTimeout time.Duration
+	// Overflow 等待超时之后的处理策略，默认是OverflowDropNewest
+	Overflow OverflowPolicy
+}
+
 type ChannelImpl struct {
 	sync.Mutex
 	id string
@@ -16,19 +41,34 @@ type ChannelImpl struct {
 	writeWait time.Duration
 	readWait time.Duration
 	closed *Event
+	options ChannelOptions
 }
 
 func NewChannel (id string, conn Conn) Channel {
+	return NewChannelWithOptions(id, conn, ChannelOptions{})
+}
+
+// NewChannelWithOptions 使用指定的写队列配置创建Channel
+func NewChannelWithOptions(id string, conn Conn, opts ChannelOptions) Channel {
 	log := logger.WithFields(logger.Fields{
 		"module": "tcp_channel",
 		"id" : id,
 	})
+	if opts.WriteQueueSize <= 0 {
+		opts.WriteQueueSize = DefaultWriteQueueSize
+	}
+	switch opts.Overflow {
+	case OverflowDropOldest, OverflowClose:
+	default:
+		opts.Overflow = OverflowDropNewest
+	}
 	ch := &ChannelImpl{
 		id : id,
 		Conn: conn,
-		writechan: make(chan []byte, 5),
+		writechan: make(chan []byte, opts.WriteQueueSize),
 		closed: NewEvent(),
 		writeWait: time.Second*10,
+		options: opts,
 	}
 	go func() {
 		// 开启一个协程 写writechan中的数据
@@ -72,13 +112,60 @@ func (ch*ChannelImpl) writeloop() error {
 }
 /**
 将将数据放入writechan 内部   // 发送数据
+队列满时最多等待PushTimeout，之后按照Overflow策略处理，不会一直阻塞调用方
  */
 func (ch *ChannelImpl) Push(payload []byte) error {
 	if ch.closed.HasFired() {
 		return errors.New("channel has closed")
 	}
-	ch.writechan <-payload
-	return nil
+	select {
+	case ch.writechan <- payload:
+		return nil
+	default:
+	}
+	if ch.options.PushTimeout > 0 {
+		timer := time.NewTimer(ch.options.PushTimeout)
+		defer timer.Stop()
+		select {
+		case ch.writechan <- payload:
+			return nil
+		case <-ch.closed.Done():
+			return errors.New("channel has closed")
+		case <-timer.C:
+		}
+	}
+	channelDroppedFrames.WithLabelValues(string(ch.options.Overflow)).Inc()
+	switch ch.options.Overflow {
+	case OverflowDropOldest:
+		for {
+			select {
+			case ch.writechan <- payload:
+				return nil
+			default:
+			}
+			select {
+			case <-ch.writechan:
+			default:
+			}
+		}
+	case OverflowClose:
+		logger.WithFields(logger.Fields{
+			"module": "tcp_channel",
+			"id":     ch.id,
+		}).Warn("write queue is full, close the channel")
+		_ = ch.Close()
+	}
+	return ErrChannelBusy
+}
+
+// Close 关闭连接，并停止writeloop
+func (ch *ChannelImpl) Close() error {
+	var err error
+	ch.once.Do(func() {
+		ch.closed.Fire()
+		err = ch.Conn.Close()
+	})
+	return err
 }
 
 // 重写了kim.Conn 中的WriteFrame 方法，增加了写超时逻辑
@@ -130,6 +217,7 @@ func (ch*ChannelImpl) Readloop(lst MessageListener) error {
 		if len(payload) == 0 {    // 如果数据为空，跳过本次
 			continue
 		}
-		go lst.Receive(ch, payload)
+		// 由Server设置的DispatchStrategy决定消息如何被处理，队列满时会阻塞读取
+		lst.Receive(ch, payload)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,107 @@
+package kingim
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockConn 写操作一直阻塞，直到release被关闭，用来模拟消费很慢的客户端
+type blockConn struct {
+	net.Conn
+	release chan struct{}
+	written chan []byte
+	closed  chan struct{}
+}
+
+func newBlockConn() *blockConn {
+	return &blockConn{
+		release: make(chan struct{}),
+		written: make(chan []byte, 100),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (c *blockConn) ReadFrame() (Frame, error) {
+	<-c.closed
+	return nil, net.ErrClosed
+}
+
+func (c *blockConn) WriteFrame(_ OpCode, payload []byte) error {
+	select {
+	case <-c.release:
+	case <-c.closed:
+		return net.ErrClosed
+	}
+	c.written <- payload
+	return nil
+}
+
+func (c *blockConn) Flush() error {
+	return nil
+}
+
+func (c *blockConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+func (c *blockConn) Close() error {
+	close(c.closed)
+	return nil
+}
+
+// fill 写满队列，writeloop会取出第一条并阻塞在WriteFrame
+func fill(t *testing.T, ch Channel, size int) {
+	assert.Nil(t, ch.Push([]byte("0")))
+	time.Sleep(time.Millisecond * 20)
+	for i := 1; i <= size; i++ {
+		assert.Nil(t, ch.Push([]byte{byte('0' + i)}))
+	}
+}
+
+func TestChannel_PushDropNewest(t *testing.T) {
+	conn := newBlockConn()
+	ch := NewChannelWithOptions("ch1", conn, ChannelOptions{WriteQueueSize: 2, PushTimeout: time.Millisecond * 10})
+	defer ch.Close()
+	fill(t, ch, 2)
+
+	err := ch.Push([]byte("3"))
+	assert.Equal(t, ErrChannelBusy, err)
+
+	close(conn.release)
+	for _, want := range []string{"0", "1", "2"} {
+		assert.Equal(t, want, string(<-conn.written))
+	}
+}
+
+func TestChannel_PushDropOldest(t *testing.T) {
+	conn := newBlockConn()
+	ch := NewChannelWithOptions("ch1", conn, ChannelOptions{WriteQueueSize: 2, Overflow: OverflowDropOldest})
+	defer ch.Close()
+	fill(t, ch, 2)
+
+	assert.Nil(t, ch.Push([]byte("3")))
+
+	close(conn.release)
+	for _, want := range []string{"0", "2", "3"} {
+		assert.Equal(t, want, string(<-conn.written))
+	}
+}
+
+func TestChannel_PushClose(t *testing.T) {
+	conn := newBlockConn()
+	ch := NewChannelWithOptions("ch1", conn, ChannelOptions{WriteQueueSize: 2, Overflow: OverflowClose})
+	fill(t, ch, 2)
+
+	err := ch.Push([]byte("3"))
+	assert.Equal(t, ErrChannelBusy, err)
+	select {
+	case <-conn.closed:
+	default:
+		t.Fatal("slow channel should be closed")
+	}
+	assert.NotNil(t, ch.Push([]byte("4")))
+	assert.Nil(t, ch.Close())
+}
@@ -5,12 +5,14 @@ import (
 	"context"
 	"fmt"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"kingim"
 	"kingim/logger"
 	"kingim/naming"
 	"kingim/tcp"
 	"kingim/wire"
 	"kingim/wire/pkt"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -44,6 +46,8 @@ type Container struct {
 	selector Selector
 	dialer kingim.Dialer
 	deps map[string]struct{}   // 依赖的服务
+	rooms kingim.RoomMap        // 网关本地的房间成员
+	apps kingim.RoomMap         // 网关本地每个app的channels
 }
 
 var log = logger.WithField("module","container")
@@ -78,9 +82,29 @@ func SerDialer(dialer kingim.Dialer) {
 	c.dialer = dialer
 }
 
+// EnableMonitor 开启监控服务，提供 /metrics 和 consul健康检查使用的 /health 接口
 func EnableMonitor(listen string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	go func() {
+		err := http.ListenAndServe(listen, mux)
+		if err != nil {
+			log.Error(err)
+		}
+	}()
 	return nil
 }
+// SetRoomMap 网关使用，收到房间消息时推送给本地加入了这个房间的channels
+func SetRoomMap(rooms kingim.RoomMap) {
+	c.rooms = rooms
+}
+// SetAppMap 网关使用，收到广播消息时推送给本地这个app所有的channels
+func SetAppMap(apps kingim.RoomMap) {
+	c.apps = apps
+}
 func SetSelector(selector Selector) {
 	c.selector = selector
 }
@@ -261,15 +285,32 @@ func pushMessage(packet *pkt.LogicPkt) error {
 	if server != c.Srv.ServiceID() {
 		return fmt.Errorf("dest_server is incorrect, %s != %s", server,c.Srv.ServiceID())
 	}
-	channels, ok := packet.GetMeta(wire.MetaDestChannels) // 信息接收方
-	if !ok {
-		return fmt.Errorf("dest_channels is nil")
+	var channelIds []string
+	if room, ok := packet.GetMeta(wire.MetaDestRoom); ok {
+		ids, err := roomChannels(room.(string), packet)
+		if err != nil {
+			return err
+		}
+		channelIds = ids
+	} else if app, ok := packet.GetMeta(wire.MetaDestBroadcast); ok {
+		if c.apps == nil {
+			return fmt.Errorf("broadcast to %s is not supported", app)
+		}
+		channelIds = c.apps.Channels(app.(string))
+	} else {
+		channels, ok := packet.GetMeta(wire.MetaDestChannels) // 信息接收方
+		if !ok {
+			return fmt.Errorf("dest_channels is nil")
+		}
+		channelIds = strings.Split(channels.(string), ",")
 	}
-	channelIds := strings.Split(channels.(string), ",")
 	packet.DelMeta(wire.MetaDestServer)
 	packet.DelMeta(wire.MetaDestChannels)
+	packet.DelMeta(wire.MetaDestRoom)
+	packet.DelMeta(wire.MetaDestBroadcast)
+	packet.DelMeta(wire.MetaSkipChannel)
 	payload := pkt.Marshal(packet)
-	log.Debugf("push to %v %v", channels, packet)
+	log.Debugf("push to %v %v", channelIds, packet)
 	for _, channel := range channelIds {
 		err := c.Srv.Push(channel, payload)   // s.Srv.Push  ch.Push  writeloop  writeFrame
 		if err != nil {
@@ -279,6 +320,42 @@ func pushMessage(packet *pkt.LogicPkt) error {
 	return nil
 }
 
+// roomChannels 加入和离开房间的响应只推送给请求方，同时更新本地的房间成员；
+// 其它房间消息推送给本地所有的成员，跳过发送方
+func roomChannels(room string, packet *pkt.LogicPkt) ([]string, error) {
+	if c.rooms == nil {
+		return nil, fmt.Errorf("room %s is not supported", room)
+	}
+	switch packet.Command {
+	case wire.CommandRoomJoin, wire.CommandRoomLeave:
+		channels, ok := packet.GetMeta(wire.MetaDestChannels)
+		if !ok {
+			return nil, fmt.Errorf("dest_channels is nil")
+		}
+		channelIds := strings.Split(channels.(string), ",")
+		if packet.Status != pkt.Status_Success {
+			return channelIds, nil
+		}
+		for _, id := range channelIds {
+			if packet.Command == wire.CommandRoomJoin {
+				c.rooms.Join(room, id)
+			} else {
+				c.rooms.Leave(room, id)
+			}
+		}
+		return channelIds, nil
+	}
+	channelIds := c.rooms.Channels(room)
+	skip, _ := packet.GetMeta(wire.MetaSkipChannel)
+	for i, id := range channelIds {
+		if id == skip {
+			channelIds = append(channelIds[:i], channelIds[i+1:]...)
+			break
+		}
+	}
+	return channelIds, nil
+}
+
 func Push(server string, p *pkt.LogicPkt) error {
 	p.AddStringMeta(wire.MetaDestServer, server) // 将要送达网关的serverName   // 在信息中添加server(服务网关)
 	return c.Srv.Push(server, pkt.Marshal(p))
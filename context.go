@@ -1,8 +1,10 @@
 package kingim
 
 import (
+	"context"
 	"google.golang.org/protobuf/proto"
 	"kingim/logger"
+	"math"
 	"kingim/wire"
 	"kingim/wire/pkt"
 	"sync"
@@ -23,6 +25,14 @@ type Session interface {
 type Context interface {
 	Dispather
 	SessionStorage
+	// Next 执行处理链中剩余的handler，只能在中间件中调用
+	Next()
+	// Abort 中断处理链，之后的handler不会再被执行
+	Abort()
+	IsAborted() bool
+	// Context 返回当前请求的context.Context，请求处理超时后会被取消，
+	// 用于控制下游调用（如royal服务）的超时
+	Context() context.Context
 	Header() *pkt.Header
 	ReadBody(val proto.Message) error
 	Session() Session
@@ -33,6 +43,9 @@ type Context interface {
 
 type HandlerFunc func(ctx Context)
 
+// 被Abort之后的index，大于任何处理链的长度
+const abortIndex = math.MaxInt32 / 2
+
 type HandlersChain []HandlerFunc
 
 type ContextImpl struct {
@@ -43,19 +56,35 @@ type ContextImpl struct {
 	index int
 	request *pkt.LogicPkt
 	session Session
+	ctx context.Context
 }
 
 func BuildContext() Context {
-	return &ContextImpl{}
+	return &ContextImpl{index: -1}
 }
 
+// Next 依次执行处理链中剩余的handler，中间件可以在调用Next前后插入自己的逻辑
 func (c*ContextImpl) Next() {
-	if c.index >= len(c.handlers) {
-		return
-	}
-	f := c.handlers[c.index]
-	f(c)
 	c.index++
+	for c.index < len(c.handlers) {
+		c.handlers[c.index](c)
+		c.index++
+	}
+}
+
+func (c*ContextImpl) Abort() {
+	c.index = abortIndex
+}
+
+func (c*ContextImpl) IsAborted() bool {
+	return c.index >= abortIndex
+}
+
+func (c*ContextImpl) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
 }
 
 func (c*ContextImpl) Header() *pkt.Header {
@@ -74,7 +103,7 @@ func (c*ContextImpl) Resp(status pkt.Status, body proto.Message) error {
 	packet.Status = status
 	packet.WriteBody(body)
 	packet.Flag = pkt.Flag_Response
-	logger.Debugf("<-- Resp to %s command:%s  status: %v body: %s\", c.Session().GetAccount(), &c.request.Header, status, body")
+	logger.Debugf("<-- Resp to %s command:%s  status: %v body: %s", c.Session().GetAccount(), &c.request.Header, status, body)
 	err := c.Push(c.Session().GetGateId(), []string{c.Session().GetChannelId()},packet)
 	if err != nil {
 		logger.Error(err)
@@ -82,42 +111,35 @@ func (c*ContextImpl) Resp(status pkt.Status, body proto.Message) error {
 	return err
 }
 
+// Dispatch 把消息推送给recvs，跳过发送方当前的连接
 func (c*ContextImpl) Dispatch(body proto.Message, recvs...*Location) error {
 	if len(recvs) == 0 {
 		return nil
 	}
-	group := make(map[string][]string)
 	packet := pkt.NewFrom(&c.request.Header)
 	packet.WriteBody(body)
 	packet.Flag = pkt.Flag_Response
 	logger.Debugf("<-- Dispatch to %d users command:%s", len(recvs), &c.request.Header)
+	targets := make([]*Location, 0, len(recvs))
 	for _,recv := range recvs {
 		if recv.ChannelId == c.Session().GetChannelId() {
 			continue
 		}
-		// 判断是否已存在
-		if _,ok := group[recv.GateId]; !ok {
-			group[recv.GateId] = make([]string, 0)
-		}
-		// 把来组相同网关的ChannelID 组合在一个数组
-		group[recv.GateId] = append(group[recv.GateId], recv.ChannelId)
+		targets = append(targets, recv)
 	}
-	// 更具 网关把信息推送出去
-	for gateway, ids := range group {
-		err := c.Push(gateway, ids, packet)
-		if err != nil {
-			logger.Error(err)
-		}
-		return err
+	err := Dispatch(c.Dispather, packet, targets...)
+	if err != nil {
+		logger.Error(err)
 	}
-	return nil
+	return err
 }
 func (c*ContextImpl) Session() Session {
 	if c.session == nil {
 		server, _ := c.request.GetMeta(wire.MetaDestServer)
+		gateId, _ := server.(string)
 		c.session = &pkt.Session{
 			ChannelId: c.request.ChannelId,
-			GateId: server.(string),
+			GateId: gateId,
 			Tags: []string{"AutoGenerated"},
 		}
 	}
@@ -125,7 +147,8 @@ func (c*ContextImpl) Session() Session {
 }
 func (c*ContextImpl) reset() {
 	c.request = nil
-	c.index = 0
-	c.handlers = nil
+	c.index = -1
+	c.handlers = c.handlers[:0]
 	c.session = nil
+	c.ctx = nil
 }
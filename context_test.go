@@ -0,0 +1,58 @@
+package kingim
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"kingim/wire"
+	"kingim/wire/pkt"
+)
+
+func TestContext_Dispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	pushed := make(map[string][]string)
+	dispather := NewMockDispather(ctrl)
+	dispather.EXPECT().Push(gomock.Any(), gomock.Any(), gomock.Any()).Times(3).DoAndReturn(func(gateway string, channels []string, p *pkt.LogicPkt) error {
+		sort.Strings(channels)
+		pushed[gateway] = channels
+		// 每个网关使用单独的包，Push中添加的meta不会带到下一个网关
+		p.AddStringMeta(wire.MetaDestChannels, "")
+		assert.Equal(t, 1, len(p.Meta))
+		var push pkt.MessagePush
+		assert.Nil(t, p.ReadBody(&push))
+		assert.Equal(t, "hello", push.Body)
+		if gateway == "gate3" {
+			return errors.New("gate3 is down")
+		}
+		return nil
+	})
+
+	var err error
+	r := NewRouter()
+	r.Handle("chat.group.talk", func(ctx Context) {
+		err = ctx.Dispatch(&pkt.MessagePush{Body: "hello"},
+			&Location{ChannelId: "ch1", GateId: "gate1"}, // 发送方当前的连接
+			&Location{ChannelId: "ch2", GateId: "gate1"}, // 发送方的其它设备
+			&Location{ChannelId: "ch3", GateId: "gate2"},
+			&Location{ChannelId: "ch4", GateId: "gate1"},
+			&Location{ChannelId: "ch4", GateId: "gate1"},
+			&Location{ChannelId: "ch5", GateId: "gate3"},
+		)
+	})
+	packet := pkt.New("chat.group.talk", pkt.WithChannel("ch1"))
+	_ = r.Serve(packet, dispather, NewMockSessionStorage(ctrl), &pkt.Session{ChannelId: "ch1", GateId: "gate1"})
+
+	assert.Equal(t, map[string][]string{
+		"gate1": {"ch2", "ch4"},
+		"gate2": {"ch3"},
+		"gate3": {"ch5"},
+	}, pushed)
+	var dispatchErr DispatchError
+	assert.True(t, errors.As(err, &dispatchErr))
+	assert.Equal(t, 1, len(dispatchErr))
+	assert.NotNil(t, dispatchErr["gate3"])
+}
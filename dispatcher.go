@@ -1,7 +1,61 @@
 package kingim
 
-import "kingim/wire/pkt"
+import (
+	"fmt"
+	"kingim/wire/pkt"
+	"sort"
+	"strings"
+)
 
 type Dispather interface {
 	Push(gateway string, channels []string, p *pkt.LogicPkt) error    // 在Dispatcher 在Router中被创建时注入进来   // 传入的是真正使用的服务的push方法
 }
+
+// Dispatch 把packet按网关分组推送给recvs，每个网关只推送一次，同一个channel只推送一次，
+// 失败的网关汇总在DispatchError中返回。Push会在包中添加目标网关和channels，因此每个网关使用packet的一个副本
+func Dispatch(d Dispather, packet *pkt.LogicPkt, recvs ...*Location) error {
+	group := make(map[string][]string)
+	seen := make(map[string]struct{}, len(recvs))
+	for _, recv := range recvs {
+		if _, ok := seen[recv.ChannelId]; ok {
+			continue
+		}
+		seen[recv.ChannelId] = struct{}{}
+		// 把来自相同网关的ChannelID组合在一个数组
+		group[recv.GateId] = append(group[recv.GateId], recv.ChannelId)
+	}
+	var errs DispatchError
+	for gateway, ids := range group {
+		p := pkt.NewFrom(&packet.Header)
+		p.Flag = packet.Flag
+		p.Body = packet.Body
+		p.Meta = append([]*pkt.Meta(nil), packet.Meta...)
+		err := d.Push(gateway, ids, p)
+		if err != nil {
+			if errs == nil {
+				errs = make(DispatchError)
+			}
+			errs[gateway] = err
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// DispatchError 推送到部分网关失败时返回，key是网关ID
+type DispatchError map[string]error
+
+func (e DispatchError) Error() string {
+	gateways := make([]string, 0, len(e))
+	for gateway := range e {
+		gateways = append(gateways, gateway)
+	}
+	sort.Strings(gateways)
+	msgs := make([]string, len(gateways))
+	for i, gateway := range gateways {
+		msgs[i] = fmt.Sprintf("%s: %v", gateway, e[gateway])
+	}
+	return fmt.Sprintf("dispatch to %d gateways failed: %s", len(e), strings.Join(msgs, "; "))
+}
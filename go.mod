@@ -3,30 +3,33 @@ module kingim
 go 1.16
 
 require (
-	github.com/bwmarrin/snowflake v0.3.0 // indirect
-	github.com/dgrijalva/jwt-go v3.2.0+incompatible // indirect
-	github.com/go-redis/redis/v7 v7.4.1 // indirect
-	github.com/go-resty/resty/v2 v2.6.0 // indirect
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/alicebob/miniredis/v2 v2.14.3
+	github.com/bwmarrin/snowflake v0.3.0
+	github.com/dgrijalva/jwt-go v3.2.0+incompatible
+	github.com/go-redis/redis/v7 v7.4.1
+	github.com/go-resty/resty/v2 v2.6.0
 	github.com/gobwas/ws v1.1.0
-	github.com/golang/mock v1.6.0 // indirect
+	github.com/golang/mock v1.6.0
 	github.com/golang/protobuf v1.5.2
 	github.com/hashicorp/consul/api v1.10.0
 	github.com/hashicorp/go-hclog v0.16.2 // indirect
-	github.com/kataras/iris/v12 v12.2.0-alpha2.0.20210705170737-afb15b860124 // indirect
-	github.com/kelseyhightower/envconfig v1.4.0 // indirect
+	github.com/kataras/iris/v12 v12.2.0-alpha2.0.20210705170737-afb15b860124
+	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/klintcheng/kim v0.0.0-20210822150849-ceaa78f630ca
-	github.com/lestrrat-go/file-rotatelogs v2.4.0+incompatible // indirect
+	github.com/lestrrat-go/file-rotatelogs v2.4.0+incompatible
 	github.com/lestrrat-go/strftime v1.0.5 // indirect
-	github.com/panjf2000/ants/v2 v2.4.6 // indirect
+	github.com/panjf2000/ants/v2 v2.4.6
 	github.com/pkg/errors v0.9.1
-	github.com/rifflock/lfshook v0.0.0-20180920164130-b9218ef580f5 // indirect
+	github.com/prometheus/client_golang v1.11.0
+	github.com/rifflock/lfshook v0.0.0-20180920164130-b9218ef580f5
 	github.com/segmentio/ksuid v1.0.4
-	github.com/sirupsen/logrus v1.8.1 // indirect
+	github.com/sirupsen/logrus v1.8.1
 	github.com/spf13/cobra v1.2.1
-	github.com/spf13/viper v1.8.1 // indirect
+	github.com/spf13/viper v1.8.1
 	github.com/stretchr/testify v1.7.0
-	google.golang.org/grpc v1.40.0 // indirect
+	google.golang.org/grpc v1.40.0
 	google.golang.org/protobuf v1.26.0
-	gorm.io/driver/mysql v1.1.2 // indirect
-	gorm.io/gorm v1.21.12 // indirect
+	gorm.io/driver/mysql v1.1.2
+	gorm.io/gorm v1.21.12
 )
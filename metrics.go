@@ -0,0 +1,20 @@
+package kingim
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// messageQueueDepth 上行消息在DispatchStrategy中排队的数量
+var messageQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "kingim",
+	Name:      "message_queue_depth",
+	Help:      "number of inbound messages waiting in the dispatch queue",
+}, []string{"mode"})
+
+// channelDroppedFrames Channel写队列满时被丢弃的消息数
+var channelDroppedFrames = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "kingim",
+	Name:      "channel_dropped_frames_total",
+	Help:      "number of outbound frames dropped because the channel write queue is full",
+}, []string{"policy"})
@@ -0,0 +1,90 @@
+package kingim
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"kingim/wire/pkt"
+)
+
+// ErrTooManyRequests 请求超过了频率限制
+var ErrTooManyRequests = errors.New("too many requests")
+
+// RateLimiter 令牌桶限流，每个key一个桶，长时间不用的桶会被清理
+type RateLimiter struct {
+	sync.Mutex
+	rate      float64 // 每秒补充的令牌数
+	burst     float64 // 桶的容量
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter 每个key每秒最多rate次，允许burst次的突发
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:      rate,
+		burst:     float64(burst),
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow 消耗key的一个令牌，没有令牌时返回false
+func (l *RateLimiter) Allow(key string) bool {
+	l.Lock()
+	defer l.Unlock()
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep 删除已经补满的桶，它们和新建的桶没有区别
+func (l *RateLimiter) sweep(now time.Time) {
+	if l.rate <= 0 || now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	full := time.Duration(l.burst / l.rate * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > full {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimit 按发送方的应用和账号限流的中间件，超过限制时回复Status_TooManyRequests并中断处理链
+func RateLimit(l *RateLimiter) HandlerFunc {
+	return func(ctx Context) {
+		// 不同应用中的同名账号是不同的用户，不能共用一个桶
+		if l.Allow(ctx.Session().GetApp() + ":" + ctx.Session().GetAccount()) {
+			return
+		}
+		_ = ctx.RespWithError(pkt.Status_TooManyRequests, ErrTooManyRequests)
+		ctx.Abort()
+	}
+}
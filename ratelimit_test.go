@@ -0,0 +1,67 @@
+package kingim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"kingim/wire/pkt"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	l := NewRateLimiter(2, 3)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.Allow("test1"))
+	}
+	assert.False(t, l.Allow("test1"))
+	// 不同的账号互不影响
+	assert.True(t, l.Allow("test2"))
+
+	// 每秒补充2个令牌
+	now = now.Add(time.Second)
+	assert.True(t, l.Allow("test1"))
+	assert.True(t, l.Allow("test1"))
+	assert.False(t, l.Allow("test1"))
+
+	// 补满的桶在清理时被删除
+	now = now.Add(time.Minute * 2)
+	assert.True(t, l.Allow("test3"))
+	assert.Equal(t, 1, len(l.buckets))
+}
+
+func TestRateLimit(t *testing.T) {
+	var calls int
+	r := NewRouter()
+	r.Handle("chat.signal", RateLimit(NewRateLimiter(0, 2)), func(ctx Context) {
+		calls++
+	})
+	for i := 0; i < 3; i++ {
+		serve(t, r, "chat.signal")
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestRateLimit_App(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dispather := NewMockDispather(ctrl)
+	dispather.EXPECT().Push(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	cache := NewMockSessionStorage(ctrl)
+
+	calls := make(map[string]int)
+	r := NewRouter()
+	r.Handle("chat.signal", RateLimit(NewRateLimiter(0, 1)), func(ctx Context) {
+		calls[ctx.Session().GetApp()]++
+	})
+	for _, app := range []string{"app1", "app2", "app1"} {
+		packet := pkt.New("chat.signal", pkt.WithChannel("channel1"))
+		err := r.Serve(packet, dispather, cache, &pkt.Session{ChannelId: "channel1", GateId: "gateway1", App: app, Account: "test1"})
+		assert.Nil(t, err)
+	}
+	// 同名账号在不同应用中分别限流
+	assert.Equal(t, map[string]int{"app1": 1, "app2": 1}, calls)
+}
@@ -0,0 +1,80 @@
+package kingim
+
+import "sync"
+
+// RoomMap 网关本地的房间成员，房间消息到达网关之后推送给这些channel
+type RoomMap interface {
+	Join(room string, channelId string)
+	Leave(room string, channelId string)
+	// LeaveAll 从所有房间中移除channel，返回它加入过的房间
+	LeaveAll(channelId string) []string
+	Channels(room string) []string
+}
+
+type RoomsImpl struct {
+	sync.RWMutex
+	// room -> channels
+	rooms map[string]map[string]struct{}
+	// channel -> rooms
+	joined map[string]map[string]struct{}
+}
+
+func NewRooms() RoomMap {
+	return &RoomsImpl{
+		rooms:  make(map[string]map[string]struct{}),
+		joined: make(map[string]map[string]struct{}),
+	}
+}
+
+func (r *RoomsImpl) Join(room string, channelId string) {
+	r.Lock()
+	defer r.Unlock()
+	setAdd(r.rooms, room, channelId)
+	setAdd(r.joined, channelId, room)
+}
+
+func (r *RoomsImpl) Leave(room string, channelId string) {
+	r.Lock()
+	defer r.Unlock()
+	setRemove(r.rooms, room, channelId)
+	setRemove(r.joined, channelId, room)
+}
+
+func (r *RoomsImpl) LeaveAll(channelId string) []string {
+	r.Lock()
+	defer r.Unlock()
+	rooms := make([]string, 0, len(r.joined[channelId]))
+	for room := range r.joined[channelId] {
+		setRemove(r.rooms, room, channelId)
+		rooms = append(rooms, room)
+	}
+	delete(r.joined, channelId)
+	return rooms
+}
+
+func (r *RoomsImpl) Channels(room string) []string {
+	r.RLock()
+	defer r.RUnlock()
+	channels := make([]string, 0, len(r.rooms[room]))
+	for id := range r.rooms[room] {
+		channels = append(channels, id)
+	}
+	return channels
+}
+
+func setAdd(m map[string]map[string]struct{}, key, value string) {
+	set, ok := m[key]
+	if !ok {
+		set = make(map[string]struct{})
+		m[key] = set
+	}
+	set[value] = struct{}{}
+}
+
+// setRemove 集合为空时删除key，避免离开的房间一直占用内存
+func setRemove(m map[string]map[string]struct{}, key, value string) {
+	delete(m[key], value)
+	if len(m[key]) == 0 {
+		delete(m, key)
+	}
+}
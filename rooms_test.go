@@ -0,0 +1,24 @@
+package kingim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRooms(t *testing.T) {
+	r := NewRooms()
+	r.Join("room1", "ch1")
+	r.Join("room1", "ch2")
+	r.Join("room2", "ch1")
+	assert.ElementsMatch(t, []string{"ch1", "ch2"}, r.Channels("room1"))
+	assert.Equal(t, []string{"ch1"}, r.Channels("room2"))
+
+	r.Leave("room1", "ch2")
+	assert.Equal(t, []string{"ch1"}, r.Channels("room1"))
+
+	assert.ElementsMatch(t, []string{"room1", "room2"}, r.LeaveAll("ch1"))
+	assert.Empty(t, r.Channels("room1"))
+	assert.Empty(t, r.Channels("room2"))
+	assert.Empty(t, r.LeaveAll("ch1"))
+}
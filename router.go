@@ -1,16 +1,30 @@
 package kingim
 
 import (
+	"context"
 	"fmt"
+	"kingim/logger"
 	"kingim/wire/pkt"
+	"runtime/debug"
 	"sync"
+	"time"
 )
 
 type Router struct {
+	RouterGroup
 	handers *FuncTree
+	middlewares HandlersChain  // 全局中间件，对所有指令生效
+	timeout time.Duration  // 单个请求的处理超时时间
 	pool sync.Pool   // 对象池，提高性能
 }
 
+// RouterGroup 指令分组，组内的指令共享指令前缀和中间件
+type RouterGroup struct {
+	prefix string
+	handlers HandlersChain
+	router *Router
+}
+
 type FuncTree struct {
 	node map[string]HandlersChain
 }
@@ -22,7 +36,9 @@ func NewTree() *FuncTree {
 func NewRouter() *Router {
 	r := &Router{
 		handers: NewTree(),
+		timeout: DefaultRequestTimeout,
 	}
+	r.RouterGroup = RouterGroup{router: r}
 	r.pool.New = func() interface{} {
 		return BuildContext()     // 创建上下文对象池
 	}
@@ -34,9 +50,10 @@ func NewRouter() *Router {
 信息分发器 Dispather
 会话管理器 SessionStorage
 发送方会话 Session
+处理链中的panic会被恢复，并给客户端回复 Status_SystemException
  */
 
-func (s*Router) Serve(packet *pkt.LogicPkt ,dispather Dispather, cache SessionStorage, session Session) error {
+func (s*Router) Serve(packet *pkt.LogicPkt ,dispather Dispather, cache SessionStorage, session Session) (err error) {
 	if dispather == nil {
 		return fmt.Errorf("dispather is nil")
 	}
@@ -49,25 +66,97 @@ func (s*Router) Serve(packet *pkt.LogicPkt ,dispather Dispather, cache SessionSt
 	ctx.SessionStorage = cache
 	ctx.session = session
 	ctx.Dispather = dispather
+	var cancel context.CancelFunc
+	ctx.ctx, cancel = context.WithTimeout(context.Background(), s.timeout)
+	// 先注册的defer最后执行，无论回复是否成功ctx都会被放回对象池
+	defer func() {
+		cancel()
+		s.pool.Put(ctx)
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			logger.WithFields(logger.Fields{
+				"module": "router",
+				"command": packet.Command,
+				"channel": packet.ChannelId,
+			}).Errorf("panic: %v\n%s", r, debug.Stack())
+			respPanic(ctx)
+			err = fmt.Errorf("panic in command %s: %v", packet.Command, r)
+		}
+	}()
 	s.serveContext(ctx)
-	s.pool.Put(ctx)
 	return nil
 }
 
-// 链路处理
+// respPanic 回复Status_SystemException，回复过程中再次panic时只记录日志
+func respPanic(ctx *ContextImpl) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.WithField("module", "router").Errorf("resp of panic failed: %v", r)
+		}
+	}()
+	_ = ctx.Resp(pkt.Status_SystemException, &pkt.ErrorResp{Message: "SystemException"})
+}
+
+// SetTimeout 设置单个请求的处理超时时间，超时后 Context.Context() 会被取消
+func (s*Router) SetTimeout(timeout time.Duration) {
+	if timeout == 0 {
+		return
+	}
+	s.timeout = timeout
+}
+
+// 链路处理，全局中间件在前，指令的处理链在后
 func (s*Router) serveContext(ctx *ContextImpl) {
+	ctx.handlers = append(ctx.handlers, s.middlewares...)
 	chain, ok := s.handers.Get(ctx.Header().Command)
 	if !ok {
-		ctx.handlers = []HandlerFunc{handleNoFound}
+		ctx.handlers = append(ctx.handlers, handleNoFound)
 		ctx.Next()
 		return
 	}
-	ctx.handlers =chain
+	ctx.handlers = append(ctx.handlers, chain...)
 	ctx.Next()   //责任链
 }
 
-func (s*Router) Handle(command string, handlers ...HandlerFunc) {
-	s.handers.Add(command,handlers)
+// Use 注册全局中间件，对所有指令（包括未注册的指令）生效
+func (s*Router) Use(middlewares ...HandlerFunc) {
+	s.middlewares = append(s.middlewares, middlewares...)
+}
+
+// Use 给分组添加中间件，只对之后在该分组中注册的指令生效
+func (g*RouterGroup) Use(middlewares ...HandlerFunc) {
+	g.handlers = append(g.handlers, middlewares...)
+}
+
+// Group 创建一个子分组，如 r.Group("chat.group") 中注册的 "create" 对应指令 chat.group.create
+func (g*RouterGroup) Group(prefix string, handlers ...HandlerFunc) *RouterGroup {
+	return &RouterGroup{
+		prefix: g.joinCommand(prefix),
+		handlers: g.combineHandlers(handlers),
+		router: g.router,
+	}
+}
+
+// Handle 注册指令的处理链，分组的中间件在前
+func (g*RouterGroup) Handle(command string, handlers ...HandlerFunc) {
+	g.router.handers.Add(g.joinCommand(command), g.combineHandlers(handlers))
+}
+
+func (g*RouterGroup) joinCommand(command string) string {
+	if g.prefix == "" {
+		return command
+	}
+	if command == "" {
+		return g.prefix
+	}
+	return g.prefix + "." + command
+}
+
+func (g*RouterGroup) combineHandlers(handlers HandlersChain) HandlersChain {
+	merged := make(HandlersChain, 0, len(g.handlers)+len(handlers))
+	merged = append(merged, g.handlers...)
+	return append(merged, handlers...)
 }
 
 func (t*FuncTree) Add(path string ,handles []HandlerFunc) {
@@ -0,0 +1,144 @@
+package kingim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"kingim/wire/pkt"
+)
+
+func serve(t *testing.T, r *Router, command string) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dispather := NewMockDispather(ctrl)
+	dispather.EXPECT().Push(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	cache := NewMockSessionStorage(ctrl)
+
+	packet := pkt.New(command, pkt.WithChannel("channel1"))
+	err := r.Serve(packet, dispather, cache, &pkt.Session{ChannelId: "channel1", GateId: "gateway1"})
+	assert.Nil(t, err)
+}
+
+func TestRouter_Middleware(t *testing.T) {
+	var calls []string
+	record := func(name string) HandlerFunc {
+		return func(ctx Context) {
+			calls = append(calls, name)
+		}
+	}
+	r := NewRouter()
+	r.Use(func(ctx Context) {
+		calls = append(calls, "global:before")
+		ctx.Next()
+		calls = append(calls, "global:after")
+	})
+	g := r.Group("chat.group", record("group"))
+	g.Handle("create", record("create"))
+	r.Handle("chat.user.talk", record("talk1"), record("talk2"))
+
+	serve(t, r, "chat.group.create")
+	assert.Equal(t, []string{"global:before", "group", "create", "global:after"}, calls)
+
+	calls = nil
+	serve(t, r, "chat.user.talk")
+	assert.Equal(t, []string{"global:before", "talk1", "talk2", "global:after"}, calls)
+
+	// 未注册的指令也会经过全局中间件
+	calls = nil
+	serve(t, r, "chat.not.found")
+	assert.Equal(t, []string{"global:before", "global:after"}, calls)
+}
+
+func TestRouter_Abort(t *testing.T) {
+	var calls []string
+	r := NewRouter()
+	auth := r.Group("chat", func(ctx Context) {
+		calls = append(calls, "auth")
+		ctx.Abort()
+	})
+	auth.Handle("user.talk", func(ctx Context) {
+		calls = append(calls, "talk")
+	})
+	r.Handle("login.signin", func(ctx Context) {
+		calls = append(calls, "signin")
+		assert.False(t, ctx.IsAborted())
+	})
+
+	serve(t, r, "chat.user.talk")
+	assert.Equal(t, []string{"auth"}, calls)
+
+	// 上下文被放回对象池之后，Abort的状态不能被带到下一个请求
+	calls = nil
+	serve(t, r, "login.signin")
+	assert.Equal(t, []string{"signin"}, calls)
+}
+
+func TestRouter_Recover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var resp *pkt.LogicPkt
+	dispather := NewMockDispather(ctrl)
+	dispather.EXPECT().Push("gateway1", []string{"channel1"}, gomock.Any()).DoAndReturn(func(gateway string, channels []string, p *pkt.LogicPkt) error {
+		resp = p
+		return nil
+	})
+	cache := NewMockSessionStorage(ctrl)
+
+	r := NewRouter()
+	r.Handle("chat.user.talk", func(ctx Context) {
+		panic("boom")
+	})
+	packet := pkt.New("chat.user.talk", pkt.WithChannel("channel1"))
+	err := r.Serve(packet, dispather, cache, &pkt.Session{ChannelId: "channel1", GateId: "gateway1"})
+	assert.NotNil(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, pkt.Status_SystemException, resp.Status)
+	assert.Equal(t, pkt.Flag_Response, resp.Flag)
+}
+
+// 回复时再次panic不能导致进程退出，ctx仍然要放回对象池
+func TestRouter_RecoverRespPanic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dispather := NewMockDispather(ctrl)
+	dispather.EXPECT().Push(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(string, []string, *pkt.LogicPkt) error {
+		panic("push failed")
+	})
+	cache := NewMockSessionStorage(ctrl)
+
+	r := NewRouter()
+	r.Handle("chat.user.talk", func(ctx Context) {
+		panic("boom")
+	})
+	// 没有session和dest.server
+	packet := pkt.New("chat.user.talk", pkt.WithChannel("channel1"))
+	err := r.Serve(packet, dispather, cache, nil)
+	assert.NotNil(t, err)
+
+	var calls int
+	r.Handle("login.signin", func(ctx Context) {
+		calls++
+		assert.False(t, ctx.IsAborted())
+	})
+	serve(t, r, "login.signin")
+	assert.Equal(t, 1, calls)
+}
+
+func TestRouter_Timeout(t *testing.T) {
+	r := NewRouter()
+	r.SetTimeout(time.Millisecond * 10)
+	var done bool
+	r.Handle("chat.user.talk", func(ctx Context) {
+		_, ok := ctx.Context().Deadline()
+		assert.True(t, ok)
+		select {
+		case <-ctx.Context().Done():
+			done = true
+		case <-time.After(time.Second):
+		}
+	})
+	serve(t, r, "chat.user.talk")
+	assert.True(t, done)
+}
@@ -7,10 +7,15 @@ import (
 )
 
 const (
-	DefaultReadWait  = time.Minute * 3
-	DefaultWriteWait = time.Second * 10
-	DefaultLoginWait = time.Second * 10
-	DefaultHeartbeat = time.Second * 55
+	DefaultReadWait       = time.Minute * 3
+	DefaultWriteWait      = time.Second * 10
+	DefaultLoginWait      = time.Second * 10
+	DefaultHeartbeat      = time.Second * 55
+	DefaultRequestTimeout = time.Second * 10
+	DefaultWriteQueueSize = 5
+
+	DefaultDispatchWorkers   = 64
+	DefaultDispatchQueueSize = 256
 )
 
 // 定义了基础服务的抽象接口
@@ -47,6 +52,10 @@ type Server interface {
 	SetReadWait(time.Duration)
 	// ChannelMap 设置Channel管理服务
 	SetChannelMap(ChannelMap)
+	// SetDispatchStrategy 设置上行消息的处理模式，默认是DispatchModeOrdered
+	SetDispatchStrategy(DispatchStrategy)
+	// SetChannelOptions 设置Channel的写队列长度、Push超时和溢出策略
+	SetChannelOptions(ChannelOptions)
 
 	// Start 用于在内部实现网络端口的监听和接收连接，
 	// 并完成一个Channel的初始化过程。
@@ -148,4 +157,4 @@ type Frame interface {
 	GetOpCode() OpCode
 	SetPayload([]byte)
 	GetPayload() []byte
-}
\ No newline at end of file
+}
@@ -428,6 +428,30 @@ func (mr *MockServerMockRecorder) SetChannelMap(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChannelMap", reflect.TypeOf((*MockServer)(nil).SetChannelMap), arg0)
 }
 
+// SetChannelOptions mocks base method.
+func (m *MockServer) SetChannelOptions(arg0 ChannelOptions) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetChannelOptions", arg0)
+}
+
+// SetChannelOptions indicates an expected call of SetChannelOptions.
+func (mr *MockServerMockRecorder) SetChannelOptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChannelOptions", reflect.TypeOf((*MockServer)(nil).SetChannelOptions), arg0)
+}
+
+// SetDispatchStrategy mocks base method.
+func (m *MockServer) SetDispatchStrategy(arg0 DispatchStrategy) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDispatchStrategy", arg0)
+}
+
+// SetDispatchStrategy indicates an expected call of SetDispatchStrategy.
+func (mr *MockServerMockRecorder) SetDispatchStrategy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDispatchStrategy", reflect.TypeOf((*MockServer)(nil).SetDispatchStrategy), arg0)
+}
+
 // SetMessageListener mocks base method.
 func (m *MockServer) SetMessageListener(arg0 MessageListener) {
 	m.ctrl.T.Helper()
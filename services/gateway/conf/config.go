@@ -8,6 +8,7 @@ import (
 	"kingim"
 	"kingim/logger"
 	"strings"
+	"time"
 )
 
 // Config Config
@@ -21,7 +22,17 @@ type Config struct {
 	ConsulURL     string
 	MonitorPort   int `default:"8001"`
 	AppSecret     string
-	LogLevel      string `default:"INFO"`
+	// 上行消息的处理模式 ordered, pool 或 goroutine
+	DispatchMode      string `default:"ordered"`
+	DispatchWorkers   int
+	DispatchQueueSize int
+	// 下行写队列的长度、队列满时的等待时间和溢出策略 drop_newest, drop_oldest 或 close
+	WriteQueueSize int `default:"5"`
+	PushTimeout    time.Duration
+	Overflow       string `default:"drop_newest"`
+	// 活跃连接的会话续期间隔，需要小于登录服务的SessionTTL
+	RefreshInterval time.Duration `default:"1m"`
+	LogLevel        string        `default:"INFO"`
 }
 
 func (c Config) String() string {
@@ -10,9 +10,13 @@ import (
 	"kingim/wire/pkt"
 	"kingim/wire/token"
 	"regexp"
+	"sync"
 	"time"
 )
 
+// refreshBatchSize 单个续期包中最多包含的channel数
+const refreshBatchSize = 1000
+
 var log = logger.WithFields(logger.Fields{
 	"service": "gateway",
 	"pkg":     "serv",
@@ -21,6 +25,13 @@ var log = logger.WithFields(logger.Fields{
 type Handel struct {
 	ServiceID string
 	AppSecret string
+	// Rooms 本地的房间成员，连接断开时移除
+	Rooms kingim.RoomMap
+	// Apps 本地每个app的channels，登录时加入，连接断开时移除，用于广播
+	Apps kingim.RoomMap
+	sync.Mutex
+	// 上次续期之后收到过消息的channel
+	active map[string]struct{}
 }
 
 func (h *Handel) Accept(conn kingim.Conn, duration time.Duration) (string, error) {
@@ -43,7 +54,7 @@ func (h *Handel) Accept(conn kingim.Conn, duration time.Duration) (string, error
 		return "", err
 	}
 	// 必须是登录包
-	if req.Command == wire.CommandLoginSignIn {
+	if req.Command != wire.CommandLoginSignIn {
 		resp := pkt.NewFrom(&req.Header)
 		resp.Status = pkt.Status_InvalidCommand
 		_ = conn.WriteFrame(kingim.OpBinary, pkt.Marshal(resp))
@@ -75,6 +86,10 @@ func (h *Handel) Accept(conn kingim.Conn, duration time.Duration) (string, error
 		GateId:    h.ServiceID,
 		App:       tk.App,
 		RemoteIP:  getIp(conn.RemoteAddr().String()),
+		Device:    login.Device,
+		Zone:      login.Zone,
+		Isp:       login.Isp,
+		Tags:      login.Tags,
 	})
 	// 转发给聊天服务
 	err = container.Forward(wire.SNLogin, req)
@@ -82,10 +97,14 @@ func (h *Handel) Accept(conn kingim.Conn, duration time.Duration) (string, error
 		log.Error(err)
 		return "", err
 	}
+	if h.Apps != nil {
+		h.Apps.Join(tk.App, id)
+	}
 	return id, nil
 }
 
 func (h *Handel) Receive(ag kingim.Agent, payload []byte) {
+	h.markActive(ag.ID())
 	buf := bytes.NewBuffer(payload)
 	packet, err := pkt.Read(buf)
 	if err != nil {
@@ -99,6 +118,15 @@ func (h *Handel) Receive(ag kingim.Agent, payload []byte) {
 	}
 	// 如果是logicPkt 就转发给逻辑服务处理   container->Froward->ForwardWithSelector->发送信息至选中的服务
 	if logicPkt, ok := packet.(*pkt.LogicPkt); ok {
+		// login.*只能由网关自己在Accept、Disconnect和续期时发出，客户端发送的直接拒绝
+		if logicPkt.ServiceName() == wire.SNLogin {
+			log.Warnf("reject %s from client %s", logicPkt.Command, ag.ID())
+			resp := pkt.NewFrom(&logicPkt.Header)
+			resp.Status = pkt.Status_InvalidCommand
+			resp.Flag = pkt.Flag_Response
+			_ = ag.Push(pkt.Marshal(resp))
+			return
+		}
 		logicPkt.ChannelId = ag.ID()
 		// 更具指令定位服务
 		err = container.Forward(logicPkt.ServiceName(), logicPkt)
@@ -115,6 +143,12 @@ func (h *Handel) Receive(ag kingim.Agent, payload []byte) {
 
 func (h *Handel) Disconnect(id string) error {
 	log.Infof("disconnect %s", id)
+	if h.Rooms != nil {
+		h.Rooms.LeaveAll(id)
+	}
+	if h.Apps != nil {
+		h.Apps.LeaveAll(id)
+	}
 	logout := pkt.New(wire.CommandLoginSignOut, pkt.WithChannel(id))
 	err := container.Forward(wire.SNLogin, logout)
 	if err != nil {
@@ -126,6 +160,54 @@ func (h *Handel) Disconnect(id string) error {
 	return nil
 }
 
+func (h *Handel) markActive(id string) {
+	h.Lock()
+	if h.active == nil {
+		h.active = make(map[string]struct{})
+	}
+	h.active[id] = struct{}{}
+	h.Unlock()
+}
+
+// RefreshLoop 每隔interval把活跃的channel分批发送给登录服务，为它们的会话续期。
+// 客户端的心跳间隔小于interval时，在线的连接在每个周期内都会被续期
+func (h *Handel) RefreshLoop(interval time.Duration, quit <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			h.refresh()
+		case <-quit:
+			return
+		}
+	}
+}
+
+func (h *Handel) refresh() {
+	h.Lock()
+	ids := make([]string, 0, len(h.active))
+	for id := range h.active {
+		ids = append(ids, id)
+	}
+	h.active = make(map[string]struct{})
+	h.Unlock()
+
+	for i := 0; i < len(ids); i += refreshBatchSize {
+		end := i + refreshBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		req := pkt.New(wire.CommandLoginRefresh, pkt.WithChannel(h.ServiceID))
+		req.WriteBody(&pkt.SessionRefreshReq{
+			ChannelIds: ids[i:end],
+		})
+		if err := container.Forward(wire.SNLogin, req); err != nil {
+			log.Warnf("refresh %d sessions failed: %v", end-i, err)
+		}
+	}
+}
+
 func generateChannelID(serviceID string, account string) string {
 	return fmt.Sprintf("%s_%s_%d", serviceID, account, wire.Seq.Next())
 }
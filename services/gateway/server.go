@@ -47,9 +47,13 @@ func RunServerStart(ctx context.Context, opts*ServerStartOptions, version string
 		Level: "info",
 		Filename: "./data/gateway.log",
 	})
+	rooms := kingim.NewRooms()
+	apps := kingim.NewRooms()
 	handler := &serv.Handel{
 		ServiceID: config.ServiceID,
 		AppSecret: config.AppSecret,
+		Rooms: rooms,
+		Apps: apps,
 	}
 	var srv kingim.Server
 	service := &naming.DefaultService{
@@ -68,7 +72,21 @@ func RunServerStart(ctx context.Context, opts*ServerStartOptions, version string
 	} else if opts.protocol == "tcp" {
 		srv = tcp.NewServer(config.Listen, service)
 	}
+	strategy, err := kingim.NewDispatchStrategy(kingim.DispatchOptions{
+		Mode: kingim.DispatchMode(config.DispatchMode),
+		Workers: config.DispatchWorkers,
+		QueueSize: config.DispatchQueueSize,
+	})
+	if err != nil {
+		return err
+	}
 	// 将方法注入服务
+	srv.SetDispatchStrategy(strategy)
+	srv.SetChannelOptions(kingim.ChannelOptions{
+		WriteQueueSize: config.WriteQueueSize,
+		PushTimeout: config.PushTimeout,
+		Overflow: kingim.OverflowPolicy(config.Overflow),
+	})
 	srv.SetReadWait(time.Minute*2)
 	srv.SetAcceptor(handler)
 	srv.SetMessageListener(handler)
@@ -81,5 +99,8 @@ func RunServerStart(ctx context.Context, opts*ServerStartOptions, version string
 	}
 	container.SetServiceNaming(ns)
 	container.SerDialer(serv.NewDialer(config.ServiceID))
+	container.SetRoomMap(rooms)
+	container.SetAppMap(apps)
+	go handler.RefreshLoop(config.RefreshInterval, ctx.Done())
 	return container.Start()
 }
\ No newline at end of file
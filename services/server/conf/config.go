@@ -27,6 +27,30 @@ type Config struct {
 	ConsulURL     string
 	RedisAddrs    string
 	RoyalURL      string
+	// 上行消息的处理模式 ordered, pool 或 goroutine
+	DispatchMode      string `default:"ordered"`
+	DispatchWorkers   int
+	DispatchQueueSize int
+	// 再次登录时的踢人策略 same_device, all 或 exceed，exceed策略下最多MaxDevices个设备同时在线
+	KickPolicy        string `default:"same_device"`
+	MaxDevices        int    `default:"3"`
+	// 会话的过期时间，网关会定时为在线的连接续期
+	SessionTTL        time.Duration `default:"5m"`
+	// 网关从注册中心消失多久之后清理它的会话
+	GatewayPurgeDelay time.Duration `default:"30s"`
+	// 推送的消息等待ack的时间和最多重推的次数
+	AckTimeout        time.Duration `default:"5s"`
+	AckMaxRetries     int           `default:"3"`
+	// 每个账号每秒最多发送的chat.signal数和允许的突发数
+	SignalRate        float64 `default:"5"`
+	SignalBurst       int     `default:"10"`
+	// 单聊和状态订阅的关系检查 anyone, not_blocked 或 friends_only，AppTalkPolicies按app覆盖
+	TalkPolicy        string `default:"anyone"`
+	AppTalkPolicies   map[string]string
+	// 后台服务调用的推送接口的监听地址，为空时不开启，只在chat服务中使用
+	PushListen        string
+	// 推送接口验证token的密钥，开启推送接口时必须配置
+	PushSecret        string
 	LogLevel      string `default:"INFO"`
 }
 
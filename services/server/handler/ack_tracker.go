@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"kingim"
+	"kingim/logger"
+	"kingim/wire"
+	"kingim/wire/pkt"
+)
+
+// AckOptions 送达确认的重试配置
+type AckOptions struct {
+	// Timeout 第一次重推前等待ack的时间，之后每次翻倍
+	Timeout time.Duration
+	// MaxRetries 最多重推的次数，超过之后由离线同步兜底
+	MaxRetries int
+	// MaxPending 等待ack的消息数上限，超过之后新的消息不再跟踪
+	MaxPending int
+}
+
+// retryBatch 每次从AckStorage中取出的到期消息数
+const retryBatch = 100
+
+// dispatchFlag ctx.Dispatch推送消息时使用的flag，重推时保持一致
+const dispatchFlag = pkt.Flag_Response
+
+// AckTracker 记录已经推送但是接收方还没有确认的消息，超时之后重新推送给接收方在线的设备，
+// 客户端需要按messageId去重。等待ack的消息保存在AckStorage中，ack按接收方的连接路由到任意节点都可以确认，
+// 节点重启也不会丢失
+type AckTracker struct {
+	dispather kingim.Dispather
+	sessions  kingim.SessionStorage
+	store     kingim.AckStorage
+	options   AckOptions
+	closed    *kingim.Event
+}
+
+func NewAckTracker(dispather kingim.Dispather, sessions kingim.SessionStorage, store kingim.AckStorage, opts AckOptions) *AckTracker {
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Second * 5
+	}
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.MaxPending <= 0 {
+		opts.MaxPending = 100000
+	}
+	t := &AckTracker{
+		dispather: dispather,
+		sessions:  sessions,
+		store:     store,
+		options:   opts,
+		closed:    kingim.NewEvent(),
+	}
+	go t.loop()
+	return t
+}
+
+// Track 开始等待app中receivers对消息的ack，只应该传入已经推送成功的在线账号，
+// command、dest和flag用于重推时构造和原消息相同的包头
+func (t *AckTracker) Track(app, command, dest string, flag pkt.Flag, push *pkt.MessagePush, receivers ...string) {
+	pending, err := t.store.Len()
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	body, err := proto.Marshal(push)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	due := millis(time.Now().Add(t.options.Timeout))
+	for _, account := range receivers {
+		if account == push.Sender {
+			continue
+		}
+		if pending >= t.options.MaxPending {
+			logger.Warnf("too many pending acks, message %d to %s is left to offline sync", push.MessageId, account)
+			continue
+		}
+		added, err := t.store.Add(&kingim.PendingAck{
+			App:       app,
+			Account:   account,
+			MessageId: push.MessageId,
+			Command:   command,
+			Dest:      dest,
+			Flag:      flag,
+			Sender:    push.Sender,
+			Push:      body,
+		}, due)
+		if err != nil {
+			logger.Warnf("track message %d to %s failed: %v", push.MessageId, account, err)
+			continue
+		}
+		if added {
+			pending++
+		}
+	}
+}
+
+// Ack 接收方确认收到消息，返回发送方，消息没有在等待ack时返回false
+func (t *AckTracker) Ack(app, account string, messageId int64) (string, bool) {
+	ack, err := t.store.Remove(app, account, messageId)
+	if err != nil {
+		logger.Warnf("ack message %d of %s failed: %v", messageId, account, err)
+		return "", false
+	}
+	if ack == nil {
+		return "", false
+	}
+	return ack.Sender, true
+}
+
+// Forget 不再等待消息的ack，例如消息已经被撤回
+func (t *AckTracker) Forget(messageId int64) {
+	if err := t.store.RemoveMessage(messageId); err != nil {
+		logger.Warnf("forget message %d failed: %v", messageId, err)
+	}
+}
+
+// Len 等待重推的消息数
+func (t *AckTracker) Len() int {
+	count, err := t.store.Len()
+	if err != nil {
+		logger.Warn(err)
+	}
+	return count
+}
+
+func (t *AckTracker) Close() {
+	t.closed.Fire()
+}
+
+func (t *AckTracker) loop() {
+	interval := t.options.Timeout / 5
+	if interval < time.Millisecond*10 {
+		interval = time.Millisecond * 10
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.retry(time.Now())
+		case <-t.closed.Done():
+			return
+		}
+	}
+}
+
+// retry 多个节点同时重推时，每条到期的消息只会被其中一个节点取走
+func (t *AckTracker) retry(now time.Time) {
+	for {
+		acks, err := t.store.Claim(millis(now), retryBatch)
+		if err != nil {
+			logger.Warn(err)
+		}
+		for _, ack := range acks {
+			t.repush(ack, now)
+		}
+		if err != nil || len(acks) < retryBatch {
+			return
+		}
+	}
+}
+
+func (t *AckTracker) repush(ack *kingim.PendingAck, now time.Time) {
+	if ack.Retries >= t.options.MaxRetries {
+		// 重试次数用完，消息已经保存在离线存储中，接收方下次同步时可以拿到
+		t.Ack(ack.App, ack.Account, ack.MessageId)
+		logger.Infof("message %d to %s is not acked after %d retries", ack.MessageId, ack.Account, ack.Retries)
+		return
+	}
+	locs, err := t.sessions.GetLocations(ack.Account)
+	if err == nil {
+		locs = inApp(locs, ack.App)
+		if len(locs) == 0 {
+			err = kingim.ErrSessionNil
+		}
+	}
+	if err == kingim.ErrSessionNil {
+		// 接收方已经离线
+		t.Ack(ack.App, ack.Account, ack.MessageId)
+		return
+	}
+	// 查询位置信息失败时同样计入重试次数，等下一次重推
+	ack.Retries++
+	due := millis(now.Add(t.options.Timeout << uint(ack.Retries)))
+	if serr := t.store.Schedule(ack, due); serr != nil {
+		logger.Warnf("schedule message %d to %s failed: %v", ack.MessageId, ack.Account, serr)
+	}
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	packet := pkt.New(ack.Command, pkt.WithDest(ack.Dest))
+	packet.Flag = ack.Flag
+	packet.Body = ack.Push
+	if err = kingim.Dispatch(t.dispather, packet, locs...); err != nil {
+		logger.Warnf("repush message %d to %s failed: %v", ack.MessageId, ack.Account, err)
+	}
+}
+
+// accountsOf 返回位置信息中的账号，去掉重复的账号
+func accountsOf(locs []*kingim.Location) []string {
+	seen := make(map[string]struct{}, len(locs))
+	accounts := make([]string, 0, len(locs))
+	for _, loc := range locs {
+		if _, ok := seen[loc.Account]; ok {
+			continue
+		}
+		seen[loc.Account] = struct{}{}
+		accounts = append(accounts, loc.Account)
+	}
+	return accounts
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// notifyDelivered 给发送方所有在线的设备推送送达回执
+func notifyDelivered(ctx kingim.Context, sender string, messageId int64) {
+	locs, err := ctx.GetLocations(sender)
+	if err != nil {
+		return
+	}
+	packet := pkt.New(wire.CommandChatTalkDelivered, pkt.WithDest(sender))
+	packet.Flag = pkt.Flag_Push
+	packet.WriteBody(&pkt.MessageDeliveredNotify{
+		MessageId:   messageId,
+		Account:     ctx.Session().GetAccount(),
+		DeliveredAt: time.Now().UnixNano(),
+	})
+	if err = kingim.Dispatch(ctx, packet, locs...); err != nil {
+		logger.Warnf("notify delivered of message %d to %s failed: %v", messageId, sender, err)
+	}
+}
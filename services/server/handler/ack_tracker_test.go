@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"kingim"
+	"kingim/storage"
+	"kingim/wire"
+	"kingim/wire/pkt"
+)
+
+type recordDispather struct {
+	sync.Mutex
+	pushed []*pkt.LogicPkt
+}
+
+func (d *recordDispather) Push(gateway string, channels []string, p *pkt.LogicPkt) error {
+	d.Lock()
+	defer d.Unlock()
+	d.pushed = append(d.pushed, p)
+	return nil
+}
+
+func (d *recordDispather) count() int {
+	d.Lock()
+	defer d.Unlock()
+	return len(d.pushed)
+}
+
+func TestAckTracker(t *testing.T) {
+	cache := storage.NewMemoryStorage(0)
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test2", ChannelId: "ch2", GateId: "gate1"})
+	// 其它app中同名的账号不影响app1中的test3
+	_ = cache.Add(&pkt.Session{App: "app2", Account: "test3", ChannelId: "ch3", GateId: "gate1"})
+	dispather := &recordDispather{}
+	acks := NewAckTracker(dispather, cache, storage.NewMemoryAckStorage(), AckOptions{Timeout: time.Millisecond * 20, MaxRetries: 2})
+	defer acks.Close()
+
+	push := &pkt.MessagePush{MessageId: 1, Sender: "test1", Body: "hello"}
+	acks.Track("app1", wire.CommandChatGroupTalk, "group1", pkt.Flag_Response, push, "test1", "test2", "test3")
+	// 发送方自己不需要ack
+	assert.Equal(t, 2, acks.Len())
+
+	// test3 不在线，第一次重推时被移除；test2 没有ack，被重推两次之后移除
+	assert.Eventually(t, func() bool {
+		return acks.Len() == 0
+	}, time.Second, time.Millisecond*10)
+	assert.Equal(t, 2, dispather.count())
+	for _, p := range dispather.pushed {
+		assert.Equal(t, wire.CommandChatGroupTalk, p.Command)
+		assert.Equal(t, "group1", p.Dest)
+		// 重推时使用和原消息相同的flag
+		assert.Equal(t, pkt.Flag_Response, p.Flag)
+		var got pkt.MessagePush
+		_ = p.ReadBody(&got)
+		assert.Equal(t, int64(1), got.MessageId)
+	}
+}
+
+func TestAckTracker_Ack(t *testing.T) {
+	cache := storage.NewMemoryStorage(0)
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test2", ChannelId: "ch2", GateId: "gate1"})
+	dispather := &recordDispather{}
+	acks := NewAckTracker(dispather, cache, storage.NewMemoryAckStorage(), AckOptions{Timeout: time.Millisecond * 50})
+	defer acks.Close()
+
+	acks.Track("app1", wire.CommandChatUserTalk, "test2", pkt.Flag_Response, &pkt.MessagePush{MessageId: 1, Sender: "test1"}, "test2")
+	acks.Track("app1", wire.CommandChatUserTalk, "test2", pkt.Flag_Response, &pkt.MessagePush{MessageId: 1, Sender: "test1"}, "test2")
+	assert.Equal(t, 1, acks.Len())
+
+	sender, ok := acks.Ack("app1", "test2", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "test1", sender)
+	// 重复的ack
+	_, ok = acks.Ack("app1", "test2", 1)
+	assert.False(t, ok)
+
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, 0, dispather.count())
+}
+
+func TestAckTracker_Forget(t *testing.T) {
+	cache := storage.NewMemoryStorage(0)
+	dispather := &recordDispather{}
+	acks := NewAckTracker(dispather, cache, storage.NewMemoryAckStorage(), AckOptions{Timeout: time.Millisecond * 50})
+	defer acks.Close()
+
+	acks.Track("app1", wire.CommandChatGroupTalk, "group1", pkt.Flag_Response, &pkt.MessagePush{MessageId: 1, Sender: "test1"}, "test2", "test3")
+	acks.Track("app1", wire.CommandChatGroupTalk, "group1", pkt.Flag_Response, &pkt.MessagePush{MessageId: 2, Sender: "test1"}, "test2")
+	assert.Equal(t, 3, acks.Len())
+
+	acks.Forget(1)
+	assert.Equal(t, 1, acks.Len())
+	_, ok := acks.Ack("app1", "test2", 2)
+	assert.True(t, ok)
+}
+
+// 多个chat节点共享AckStorage，ack可以由其它节点确认，到期的消息只被一个节点重推
+func TestAckTracker_SharedStorage(t *testing.T) {
+	cache := storage.NewMemoryStorage(0)
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test2", ChannelId: "ch2", GateId: "gate1"})
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test3", ChannelId: "ch3", GateId: "gate1"})
+	store := storage.NewMemoryAckStorage()
+	dispather := &recordDispather{}
+	opts := AckOptions{Timeout: time.Millisecond * 20, MaxRetries: 1}
+	node1 := NewAckTracker(dispather, cache, store, opts)
+	defer node1.Close()
+	node2 := NewAckTracker(dispather, cache, store, opts)
+	defer node2.Close()
+
+	node1.Track("app1", wire.CommandChatGroupTalk, "group1", pkt.Flag_Response, &pkt.MessagePush{MessageId: 1, Sender: "test1"}, "test2", "test3")
+	sender, ok := node2.Ack("app1", "test2", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "test1", sender)
+
+	assert.Eventually(t, func() bool {
+		return node1.Len() == 0 && node2.Len() == 0
+	}, time.Second, time.Millisecond*10)
+	time.Sleep(time.Millisecond * 50)
+	// 只有test3被重推了一次
+	assert.Equal(t, 1, dispather.count())
+}
+
+func Test_accountsOf(t *testing.T) {
+	locs := []*kingim.Location{
+		{Account: "test1", ChannelId: "ch1"},
+		{Account: "test2", ChannelId: "ch2"},
+		{Account: "test1", ChannelId: "ch3"},
+	}
+	assert.Equal(t, []string{"test1", "test2"}, accountsOf(locs))
+}
@@ -3,29 +3,59 @@ package handler
 import (
 	"errors"
 	"kingim"
+	"kingim/logger"
 	"kingim/services/server/service"
+	"kingim/wire"
 	"kingim/wire/pkt"
 	"kingim/wire/rpc"
 	"time"
+
+	"google.golang.org/protobuf/proto"
 )
 
-var ErrNoDestination = errors.New("dest is empty")
+var (
+	ErrNoDestination = errors.New("dest is empty")
+	ErrTalkNotAllowed = errors.New("not allowed to talk to the account")
+	ErrGroupMuted = errors.New("the group is muted")
+	ErrMemberMuted = errors.New("you are muted in the group")
+	ErrNotGroupMember = errors.New("not a member of the group")
+)
 
 type ChatHandler struct {
 	msgService service.Message
 	groupService service.Group
+	acks *AckTracker
+	talk TalkOptions
 }
 
-func NewChatHandler(message service.Message, group service.Group) *ChatHandler {
+// NewChatHandler acks为nil时不跟踪消息的送达
+func NewChatHandler(message service.Message, group service.Group, acks *AckTracker) *ChatHandler {
 	return &ChatHandler{
 		msgService: message,
 		groupService: group,
+		acks: acks,
+		talk: TalkOptions{Policy: TalkAnyone},
+	}
+}
+
+// SetTalkOptions 设置单聊时对双方关系的检查，默认不检查
+func (c*ChatHandler) SetTalkOptions(opts TalkOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
 	}
+	c.talk = opts
+	return nil
+}
+
+// talkAllowed 按app的策略检查发送方是否可以给receiver发消息
+func (c*ChatHandler) talkAllowed(ctx kingim.Context, receiver string) (bool, error) {
+	return c.talk.allowed(ctx.Context(), ctx.Session().GetApp(), ctx.Session().GetAccount(), receiver)
 }
 
 func (c*ChatHandler) DoUserTalk(ctx kingim.Context) {
 	if ctx.Header().Dest == "" {
 		_ = ctx.RespWithError(pkt.Status_NoDestination, ErrNoDestination)
+		return
 	}
 	// 解包
 	var req pkt.MessageReq
@@ -33,16 +63,25 @@ func (c*ChatHandler) DoUserTalk(ctx kingim.Context) {
 		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
 		return
 	}
-	// 获取接收方位置信息
 	receiver := ctx.Header().GetDest()
-	loc, err := ctx.GetLocation(receiver, "")
+	allowed, err := c.talkAllowed(ctx, receiver)
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	if !allowed {
+		_ = ctx.RespWithError(pkt.Status_Unauthorized, ErrTalkNotAllowed)
+		return
+	}
+	// 获取接收方所有在线设备的位置信息，发送方的其它设备也会收到这条消息
+	locs, err := ctx.GetLocations(receiver, ctx.Session().GetAccount())
 	if err != nil && err != kingim.ErrSessionNil {
-		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
 		return
 	}
 	// 保存离线信息
 	sendTime := time.Now().UnixNano()
-	resp, err := c.msgService.InsertUser(ctx.Session().GetApp(), &rpc.InsertMessageReq{
+	resp, err := c.msgService.InsertUser(ctx.Context(), ctx.Session().GetApp(), &rpc.InsertMessageReq{
 		Sender: ctx.Session().GetAccount(),
 		Dest: receiver,
 		SendTime: sendTime,
@@ -56,19 +95,23 @@ func (c*ChatHandler) DoUserTalk(ctx kingim.Context) {
 		_ = ctx.RespWithError(pkt.Status_SystemException, err)
 		return
 	}
-	// 如果接收方在线 将信息发送过去
+	// 如果接收方在线 将信息发送过去，消息已经保存为离线消息，推送失败时接收方可以通过离线同步拿到
 	var messageId int64 = resp.MessageId
-	if loc != nil {
-		if err = ctx.Dispatch(&pkt.MessagePush{
+	if len(locs) > 0 {
+		push := &pkt.MessagePush{
 			MessageId: messageId,
 			Type: req.GetType(),
 			Body: req.GetBody(),
 			Extra: req.GetExtra(),
 			Sender: ctx.Session().GetAccount(),    // 发送方
 			SendTime: sendTime,
-		}, loc);err != nil {
-			_ = ctx.RespWithError(pkt.Status_SystemException, err)
-			return
+		}
+		if err = ctx.Dispatch(push, locs...);err != nil {
+			logger.Warnf("dispatch message %d to %s failed: %v", messageId, receiver, err)
+		}
+		// 只等待已经推送的在线账号的ack，locs中也包含发送方的其它设备
+		if c.acks != nil {
+			c.acks.Track(ctx.Session().GetApp(), ctx.Header().GetCommand(), receiver, dispatchFlag, push, accountsOf(locs)...)
 		}
 	}
 	// 给发送方回应一条信息
@@ -80,7 +123,8 @@ func (c*ChatHandler) DoUserTalk(ctx kingim.Context) {
 
 func (h *ChatHandler) DoGroupTalk(ctx kingim.Context) {
 	if ctx.Header().Dest == "" {
-		_ = ctx.RespWithError(pkt.Status_SystemException, ErrNoDestination)
+		_ = ctx.RespWithError(pkt.Status_NoDestination, ErrNoDestination)
+		return
 	}
 	// 解包
 	var req pkt.MessageReq
@@ -91,9 +135,27 @@ func (h *ChatHandler) DoGroupTalk(ctx kingim.Context) {
 	// 获取位置信息
 	// 群聊里面的dest是群id
 	group := ctx.Header().GetDest()
+	// 读取成员列表
+	memberResp, err := h.groupService.Members(ctx.Context(), ctx.Session().GetApp(), &rpc.GroupMembersReq{
+		GroupId: group,
+	})
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	// 被禁言时消息不保存
+	switch err = mutedError(memberResp, ctx.Session().GetAccount(), time.Now()); err {
+	case nil:
+	case ErrNotGroupMember:
+		_ = ctx.RespWithError(pkt.Status_Unauthorized, err)
+		return
+	default:
+		_ = ctx.RespWithError(pkt.Status_Muted, err)
+		return
+	}
 	sendTime := time.Now().UnixNano()
 	// 根据群id来储存离线信息
-	resp, err := h.msgService.InsertGroup(ctx.Session().GetApp(), &rpc.InsertMessageReq{
+	resp, err := h.msgService.InsertGroup(ctx.Context(), ctx.Session().GetApp(), &rpc.InsertMessageReq{
 		Sender:   ctx.Session().GetAccount(),
 		Dest:     group,
 		SendTime: sendTime,
@@ -103,10 +165,6 @@ func (h *ChatHandler) DoGroupTalk(ctx kingim.Context) {
 			Extra: req.GetExtra(),
 		},
 	})
-	// 读取成员列表
-	memberResp, err := h.groupService.Members(ctx.Session().GetApp(), &rpc.GroupMembersReq{
-		GroupId: group,
-	})
 	if err != nil {
 		_ = ctx.RespWithError(pkt.Status_SystemException, err)
 		return
@@ -115,22 +173,27 @@ func (h *ChatHandler) DoGroupTalk(ctx kingim.Context) {
 	for i, user := range memberResp.Users {
 		members[i] = user.Account
 	}
+	// 成员中包含发送方，因此发送方的其它设备也会收到这条消息
 	locs, err := ctx.GetLocations(members...)
-	if err != nil {
+	if err != nil && err != kingim.ErrSessionNil {
 		_ = ctx.RespWithError(pkt.Status_SystemException, err)
 		return
 	}
 	if len(locs) > 0 {
-		if err = ctx.Dispatch(&pkt.MessagePush{
+		push := &pkt.MessagePush{
 			MessageId: resp.MessageId,
 			Type: req.GetType(),
 			Body: req.GetBody(),
 			Extra: req.GetExtra(),
 			Sender: ctx.Session().GetAccount(),
 			SendTime: sendTime,
-		}); err != nil {
-			_ = ctx.RespWithError(pkt.Status_SystemException,err)
-			return
+		}
+		if err = ctx.Dispatch(push, locs...); err != nil {
+			logger.Warnf("dispatch message %d to group %s failed: %v", resp.MessageId, group, err)
+		}
+		// 离线的成员不会ack，由离线同步拿到消息
+		if h.acks != nil {
+			h.acks.Track(ctx.Session().GetApp(), ctx.Header().GetCommand(), group, dispatchFlag, push, accountsOf(locs)...)
 		}
 	}
 	_ = ctx.Resp(pkt.Status_Success, &pkt.MessageResp{
@@ -139,13 +202,39 @@ func (h *ChatHandler) DoGroupTalk(ctx kingim.Context) {
 	})
 }
 
+// mutedError 全群禁言时群主和管理员仍然可以发言，不是群成员时不能发言
+func mutedError(resp *rpc.GroupMembersResp, sender string, now time.Time) error {
+	for _, m := range resp.GetUsers() {
+		if m.Account != sender {
+			continue
+		}
+		if m.Role >= wire.GroupRoleAdmin {
+			return nil
+		}
+		if resp.GetMuted() {
+			return ErrGroupMuted
+		}
+		if m.MutedUntil > now.Unix() {
+			return ErrMemberMuted
+		}
+		return nil
+	}
+	return ErrNotGroupMember
+}
+
 func (h*ChatHandler) DoTalkAck(ctx kingim.Context) {
 	var req pkt.MessageAckReq
 	if err := ctx.ReadBody(&req); err!= nil {
 		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
 		return
 	}
-	err := h.msgService.SetAck(ctx.Session().GetApp(), &rpc.AckMessageReq{
+	// 第一次确认时给发送方推送送达回执，重复的ack不会再通知
+	if h.acks != nil {
+		if sender, ok := h.acks.Ack(ctx.Session().GetApp(), ctx.Session().GetAccount(), req.GetMessageId()); ok {
+			notifyDelivered(ctx, sender, req.GetMessageId())
+		}
+	}
+	err := h.msgService.SetAck(ctx.Context(), ctx.Session().GetApp(), &rpc.AckMessageReq{
 		Account:   ctx.Session().GetAccount(),
 		MessageId: req.GetMessageId(),
 	})
@@ -154,4 +243,194 @@ func (h*ChatHandler) DoTalkAck(ctx kingim.Context) {
 		return
 	}
 	_ = ctx.Resp(pkt.Status_Success,nil)
-}
\ No newline at end of file
+}
+
+func (h*ChatHandler) DoRecall(ctx kingim.Context) {
+	var req pkt.MessageRecallReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if req.GetMessageId() == 0 {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("message_id is required"))
+		return
+	}
+	account := ctx.Session().GetAccount()
+	resp, err := h.msgService.Recall(ctx.Context(), ctx.Session().GetApp(), &rpc.RecallMessageReq{
+		Account: account,
+		MessageId: req.GetMessageId(),
+	})
+	switch err {
+	case nil:
+	case service.ErrForbidden:
+		_ = ctx.RespWithError(pkt.Status_Unauthorized, errors.New("only the sender can recall the message"))
+		return
+	case service.ErrExpired:
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("recall window expired"))
+		return
+	default:
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	// 撤回的消息不再重推
+	if h.acks != nil {
+		h.acks.Forget(req.GetMessageId())
+	}
+	// 离线的接收方同步消息内容时拿到的是墓碑
+	h.notify(ctx, resp.GetDest(), resp.GetGroup(), &pkt.MessageRecallNotify{
+		MessageId: req.GetMessageId(),
+		Sender: account,
+		Dest: resp.GetDest(),
+		Group: resp.GetGroup(),
+		RecalledAt: time.Now().UnixNano(),
+	})
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h*ChatHandler) DoEdit(ctx kingim.Context) {
+	var req pkt.MessageEditReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if req.GetMessageId() == 0 || req.GetBody() == "" {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("message_id and body are required"))
+		return
+	}
+	account := ctx.Session().GetAccount()
+	resp, err := h.msgService.Edit(ctx.Context(), ctx.Session().GetApp(), &rpc.EditMessageReq{
+		Account: account,
+		MessageId: req.GetMessageId(),
+		Body: req.GetBody(),
+		Extra: req.GetExtra(),
+	})
+	switch err {
+	case nil:
+	case service.ErrForbidden:
+		_ = ctx.RespWithError(pkt.Status_Unauthorized, errors.New("only the sender can edit the message"))
+		return
+	case service.ErrInvalid:
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("only text messages that are not recalled can be edited"))
+		return
+	default:
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	h.notify(ctx, resp.GetDest(), resp.GetGroup(), &pkt.MessageEditNotify{
+		MessageId: req.GetMessageId(),
+		Sender: account,
+		Dest: resp.GetDest(),
+		Group: resp.GetGroup(),
+		Body: req.GetBody(),
+		Extra: req.GetExtra(),
+		Revision: resp.GetRevision(),
+		EditedAt: resp.GetEditedAt(),
+	})
+	_ = ctx.Resp(pkt.Status_Success, &pkt.MessageEditResp{
+		Revision: resp.GetRevision(),
+		EditedAt: resp.GetEditedAt(),
+	})
+}
+
+func (h*ChatHandler) DoRead(ctx kingim.Context) {
+	var req pkt.ReadReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if req.GetDest() == "" || req.GetMessageId() == 0 {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("dest and message_id are required"))
+		return
+	}
+	account := ctx.Session().GetAccount()
+	resp, err := h.msgService.SetRead(ctx.Context(), ctx.Session().GetApp(), &rpc.ReadMessageReq{
+		Account: account,
+		Dest: req.GetDest(),
+		Group: req.GetGroup(),
+		MessageId: req.GetMessageId(),
+	})
+	if err == service.ErrNotFound {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("message not found in this conversation"))
+		return
+	}
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	// 已读位置没有前进时不需要通知
+	if resp.GetUpdated() {
+		h.notify(ctx, req.GetDest(), req.GetGroup(), &pkt.ReadReceiptNotify{
+			Account: account,
+			Dest: req.GetDest(),
+			Group: req.GetGroup(),
+			MessageId: req.GetMessageId(),
+			ReadAt: time.Now().UnixNano(),
+		})
+	}
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+// notify 单聊通知对方，群聊通知群成员，自己的其它设备也会收到用于同步状态
+func (h*ChatHandler) notify(ctx kingim.Context, dest string, group bool, body proto.Message) {
+	receivers := []string{dest, ctx.Session().GetAccount()}
+	if group {
+		members, err := h.groupService.Members(ctx.Context(), ctx.Session().GetApp(), &rpc.GroupMembersReq{
+			GroupId: dest,
+		})
+		if err != nil {
+			logger.Warn(err)
+			return
+		}
+		receivers = make([]string, len(members.GetUsers()))
+		for i, user := range members.GetUsers() {
+			receivers[i] = user.Account
+		}
+	}
+	locs, err := ctx.GetLocations(receivers...)
+	if err != nil {
+		return
+	}
+	if err = ctx.Dispatch(body, locs...); err != nil {
+		logger.Warnf("notify %s of %s failed: %v", ctx.Header().GetCommand(), dest, err)
+	}
+}
+
+// DoSignal 转发正在输入等瞬时信号，不保存也不跟踪送达，接收方不在线时直接丢弃
+func (h*ChatHandler) DoSignal(ctx kingim.Context) {
+	if ctx.Header().Dest == "" {
+		_ = ctx.RespWithError(pkt.Status_NoDestination, ErrNoDestination)
+		return
+	}
+	var req pkt.SignalReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	// 和单聊消息使用相同的关系检查
+	allowed, err := h.talkAllowed(ctx, ctx.Header().GetDest())
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	if !allowed {
+		_ = ctx.RespWithError(pkt.Status_Unauthorized, ErrTalkNotAllowed)
+		return
+	}
+	locs, err := ctx.GetLocations(ctx.Header().GetDest())
+	if err != nil && err != kingim.ErrSessionNil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	if len(locs) > 0 {
+		err = ctx.Dispatch(&pkt.SignalNotify{
+			Sender: ctx.Session().GetAccount(),
+			Type: req.GetType(),
+			Extra: req.GetExtra(),
+			SentAt: time.Now().UnixNano(),
+		}, locs...)
+		if err != nil {
+			logger.Debugf("dispatch signal to %s failed: %v", ctx.Header().GetDest(), err)
+		}
+	}
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"kingim"
+	"kingim/storage"
+	"kingim/wire"
+	"kingim/wire/pkt"
+	"kingim/wire/rpc"
+)
+
+func Test_mutedError(t *testing.T) {
+	now := time.Now()
+	resp := &rpc.GroupMembersResp{
+		Users: []*rpc.Member{
+			{Account: "owner", Role: wire.GroupRoleOwner},
+			{Account: "admin", Role: wire.GroupRoleAdmin, MutedUntil: now.Unix() + 60},
+			{Account: "test1", MutedUntil: now.Unix() + 60},
+			{Account: "test2", MutedUntil: now.Unix() - 1},
+		},
+	}
+	assert.Nil(t, mutedError(resp, "owner", now))
+	assert.Nil(t, mutedError(resp, "admin", now))
+	assert.Equal(t, ErrMemberMuted, mutedError(resp, "test1", now))
+	// 禁言已经过期
+	assert.Nil(t, mutedError(resp, "test2", now))
+
+	resp.Muted = true
+	assert.Nil(t, mutedError(resp, "owner", now))
+	assert.Nil(t, mutedError(resp, "admin", now))
+	assert.Equal(t, ErrGroupMuted, mutedError(resp, "test2", now))
+
+	// 不是群成员
+	assert.Equal(t, ErrNotGroupMember, mutedError(resp, "test3", now))
+	resp.Muted = false
+	assert.Equal(t, ErrNotGroupMember, mutedError(resp, "test3", now))
+}
+
+func TestChatHandler_DoSignal(t *testing.T) {
+	cache := storage.NewMemoryStorage(0)
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test2", ChannelId: "ch2", GateId: "gate1"})
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test3", ChannelId: "ch3", GateId: "gate1"})
+	h := NewChatHandler(nil, nil, nil)
+	err := h.SetTalkOptions(TalkOptions{
+		Contact: fakeContact{friends: map[string]bool{"test1,test2": true}},
+		Apps:    map[string]TalkPolicy{"app1": TalkFriendsOnly},
+	})
+	assert.Nil(t, err)
+	r := kingim.NewRouter()
+	r.Handle(wire.CommandChatSignal, h.DoSignal)
+
+	serve := func(dest string) []*pkt.LogicPkt {
+		dispather := &recordDispather{}
+		packet := pkt.New(wire.CommandChatSignal, pkt.WithChannel("ch1"), pkt.WithDest(dest))
+		packet.WriteBody(&pkt.SignalReq{Type: 1})
+		assert.Nil(t, r.Serve(packet, dispather, cache, &pkt.Session{App: "app1", Account: "test1", ChannelId: "ch1", GateId: "gate1"}))
+		return dispather.pushed
+	}
+
+	// 好友之间可以发送信号
+	pushed := serve("test2")
+	assert.Equal(t, 2, len(pushed))
+	assert.Equal(t, pkt.Status_Success, pushed[1].Status)
+
+	// 不是好友时和单聊消息一样被拒绝
+	pushed = serve("test3")
+	assert.Equal(t, 1, len(pushed))
+	assert.Equal(t, pkt.Status_Unauthorized, pushed[0].Status)
+}
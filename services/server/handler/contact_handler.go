@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"kingim"
+	"kingim/logger"
+	"kingim/services/server/service"
+	"kingim/wire/pkt"
+	"kingim/wire/rpc"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// TalkPolicy 单聊时对双方关系的要求
+type TalkPolicy string
+
+const (
+	// TalkAnyone 任何账号之间都可以单聊
+	TalkAnyone TalkPolicy = "anyone"
+	// TalkNotBlocked 被接收方拉黑之后不能再给对方发消息
+	TalkNotBlocked TalkPolicy = "not_blocked"
+	// TalkFriendsOnly 只有互为好友才能单聊
+	TalkFriendsOnly TalkPolicy = "friends_only"
+)
+
+// TalkOptions 单聊的关系检查配置
+type TalkOptions struct {
+	Contact service.Contact
+	// Policy 默认的策略
+	Policy TalkPolicy
+	// Apps 按app覆盖默认的策略
+	Apps map[string]TalkPolicy
+}
+
+func (o TalkOptions) policy(app string) TalkPolicy {
+	if p, ok := o.Apps[app]; ok {
+		return p
+	}
+	return o.Policy
+}
+
+// allowed 按app的策略检查account是否可以给peer发消息
+func (o TalkOptions) allowed(ctx context.Context, app, account, peer string) (bool, error) {
+	policy := o.policy(app)
+	if policy == TalkAnyone {
+		return true, nil
+	}
+	rel, err := o.Contact.Relation(ctx, app, account, peer)
+	if err != nil {
+		return false, err
+	}
+	if rel.GetBlocked() {
+		return false, nil
+	}
+	return policy != TalkFriendsOnly || rel.GetFriend(), nil
+}
+
+func (o *TalkOptions) validate() error {
+	if o.Policy == "" {
+		o.Policy = TalkAnyone
+	}
+	policies := []TalkPolicy{o.Policy}
+	for _, p := range o.Apps {
+		policies = append(policies, p)
+	}
+	for _, p := range policies {
+		switch p {
+		case TalkAnyone:
+		case TalkNotBlocked, TalkFriendsOnly:
+			if o.Contact == nil {
+				return fmt.Errorf("contact service is required by talk policy %s", p)
+			}
+		default:
+			return fmt.Errorf("unknown talk policy %s", p)
+		}
+	}
+	return nil
+}
+
+type ContactHandler struct {
+	contactService service.Contact
+}
+
+func NewContactHandler(contact service.Contact) *ContactHandler {
+	return &ContactHandler{contactService: contact}
+}
+
+// DoRequest 发送好友请求，对方在线时推送ContactRequestNotify
+func (h *ContactHandler) DoRequest(ctx kingim.Context) {
+	var req pkt.ContactRequestReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	account := ctx.Session().GetAccount()
+	if req.GetAccount() == "" || req.GetAccount() == account {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("invalid account"))
+		return
+	}
+	resp, err := h.contactService.Request(ctx.Context(), ctx.Session().GetApp(), &rpc.FriendRequestReq{
+		From:    account,
+		To:      req.GetAccount(),
+		Message: req.GetMessage(),
+	})
+	switch err {
+	case nil:
+	case service.ErrForbidden:
+		_ = ctx.RespWithError(pkt.Status_Unauthorized, errors.New("blocked by the account"))
+		return
+	case service.ErrConflict:
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("already friends"))
+		return
+	case service.ErrInvalid:
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("invalid account"))
+		return
+	default:
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	notifyAccount(ctx, req.GetAccount(), &pkt.ContactRequestNotify{
+		RequestId: resp.GetRequestId(),
+		From:      account,
+		Message:   req.GetMessage(),
+	})
+	_ = ctx.Resp(pkt.Status_Success, &pkt.ContactRequestResp{RequestId: resp.GetRequestId()})
+}
+
+// DoHandle 接受或者拒绝好友请求，结果推送给发起请求的账号
+func (h *ContactHandler) DoHandle(ctx kingim.Context) {
+	var req pkt.ContactHandleReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	account := ctx.Session().GetAccount()
+	resp, err := h.contactService.Handle(ctx.Context(), ctx.Session().GetApp(), &rpc.HandleFriendRequestReq{
+		Account:   account,
+		RequestId: req.GetRequestId(),
+		Accept:    req.GetAccept(),
+	})
+	if err == service.ErrNotFound {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("friend request not found"))
+		return
+	}
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	notifyAccount(ctx, resp.GetFrom(), &pkt.ContactHandledNotify{
+		RequestId: req.GetRequestId(),
+		Account:   account,
+		Accepted:  req.GetAccept(),
+	})
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h *ContactHandler) DoRequests(ctx kingim.Context) {
+	resp, err := h.contactService.Requests(ctx.Context(), ctx.Session().GetApp(), ctx.Session().GetAccount())
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	var list = make([]*pkt.FriendRequest, len(resp.List))
+	for i, val := range resp.List {
+		list[i] = &pkt.FriendRequest{
+			Id:        val.Id,
+			From:      val.From,
+			Message:   val.Message,
+			CreatedAt: val.CreatedAt,
+		}
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.ContactRequestsResp{Requests: list})
+}
+
+func (h *ContactHandler) DoList(ctx kingim.Context) {
+	resp, err := h.contactService.List(ctx.Context(), ctx.Session().GetApp(), ctx.Session().GetAccount())
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	var list = make([]*pkt.Contact, len(resp.List))
+	for i, val := range resp.List {
+		list[i] = &pkt.Contact{
+			Account:   val.Account,
+			Remark:    val.Remark,
+			Friend:    val.Friend,
+			Blocked:   val.Blocked,
+			CreatedAt: val.CreatedAt,
+		}
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.ContactListResp{Contacts: list})
+}
+
+func (h *ContactHandler) DoRemove(ctx kingim.Context) {
+	var req pkt.ContactRemoveReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if req.GetAccount() == "" {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("account is required"))
+		return
+	}
+	err := h.contactService.Remove(ctx.Context(), ctx.Session().GetApp(), &rpc.ContactReq{
+		Account: ctx.Session().GetAccount(),
+		Peer:    req.GetAccount(),
+	})
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+// DoBlock 拉黑不会通知对方
+func (h *ContactHandler) DoBlock(ctx kingim.Context) {
+	var req pkt.ContactBlockReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if req.GetAccount() == "" {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("account is required"))
+		return
+	}
+	err := h.contactService.Block(ctx.Context(), ctx.Session().GetApp(), &rpc.BlockContactReq{
+		Account: ctx.Session().GetAccount(),
+		Peer:    req.GetAccount(),
+		Blocked: req.GetBlocked(),
+	})
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h *ContactHandler) DoRemark(ctx kingim.Context) {
+	var req pkt.ContactRemarkReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if req.GetAccount() == "" {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("account is required"))
+		return
+	}
+	err := h.contactService.Remark(ctx.Context(), ctx.Session().GetApp(), &rpc.RemarkContactReq{
+		Account: ctx.Session().GetAccount(),
+		Peer:    req.GetAccount(),
+		Remark:  req.GetRemark(),
+	})
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+// notifyAccount 推送给account所有在线的设备，不在线时不通知
+func notifyAccount(ctx kingim.Context, account string, body proto.Message) {
+	locs, err := ctx.GetLocations(account)
+	if err != nil {
+		return
+	}
+	if err = ctx.Dispatch(body, locs...); err != nil {
+		logger.Warnf("notify %s to %s failed: %v", ctx.Header().GetCommand(), account, err)
+	}
+}
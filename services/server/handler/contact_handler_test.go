@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"kingim/services/server/service"
+	"kingim/wire/rpc"
+)
+
+type fakeContact struct {
+	service.Contact
+	// friends 互为好友的账号，key为两个账号用逗号连接
+	friends map[string]bool
+}
+
+func (c fakeContact) Relation(ctx context.Context, app string, account, peer string) (*rpc.RelationResp, error) {
+	return &rpc.RelationResp{Friend: c.friends[account+","+peer] || c.friends[peer+","+account]}, nil
+}
+
+func TestChatHandler_SetTalkOptions(t *testing.T) {
+	h := NewChatHandler(nil, nil, nil)
+	assert.Equal(t, TalkAnyone, h.talk.policy("app1"))
+
+	// 需要检查关系的策略必须配置Contact
+	err := h.SetTalkOptions(TalkOptions{Policy: TalkFriendsOnly})
+	assert.NotNil(t, err)
+	err = h.SetTalkOptions(TalkOptions{Apps: map[string]TalkPolicy{"app1": TalkNotBlocked}})
+	assert.NotNil(t, err)
+	err = h.SetTalkOptions(TalkOptions{Policy: "unknown"})
+	assert.NotNil(t, err)
+
+	err = h.SetTalkOptions(TalkOptions{
+		Contact: fakeContact{},
+		Apps:    map[string]TalkPolicy{"app1": TalkFriendsOnly},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, TalkFriendsOnly, h.talk.policy("app1"))
+	assert.Equal(t, TalkAnyone, h.talk.policy("app2"))
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"errors"
+
+	"kingim"
+	"kingim/services/server/service"
+	"kingim/wire/pkt"
+	"kingim/wire/rpc"
+)
+
+type ConversationHandler struct {
+	msgService service.Message
+}
+
+func NewConversationHandler(message service.Message) *ConversationHandler {
+	return &ConversationHandler{msgService: message}
+}
+
+func (h *ConversationHandler) DoList(ctx kingim.Context) {
+	var req pkt.ConversationListReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	resp, err := h.msgService.ListConversations(ctx.Context(), ctx.Session().GetApp(), &rpc.ConversationListReq{
+		Account: ctx.Session().GetAccount(),
+		Cursor:  req.GetCursor(),
+		Limit:   req.GetLimit(),
+	})
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	var list = make([]*pkt.Conversation, len(resp.List))
+	for i, val := range resp.List {
+		list[i] = &pkt.Conversation{
+			Dest:          val.Dest,
+			Group:         val.Group,
+			LastMessageId: val.LastMessageId,
+			LastSender:    val.LastSender,
+			LastType:      val.LastType,
+			LastBody:      val.LastBody,
+			LastSendTime:  val.LastSendTime,
+			Unread:        val.Unread,
+			Pinned:        val.Pinned,
+			Muted:         val.Muted,
+		}
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.ConversationListResp{
+		List:       list,
+		NextCursor: resp.NextCursor,
+	})
+}
+
+func (h *ConversationHandler) DoUpdate(ctx kingim.Context) {
+	var req pkt.ConversationUpdateReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if req.GetDest() == "" {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("dest is required"))
+		return
+	}
+	if req.Pinned == nil && req.Muted == nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("pinned or muted is required"))
+		return
+	}
+	err := h.msgService.UpdateConversation(ctx.Context(), ctx.Session().GetApp(), &rpc.ConversationUpdateReq{
+		Account: ctx.Session().GetAccount(),
+		Dest:    req.GetDest(),
+		Group:   req.GetGroup(),
+		Pinned:  req.Pinned,
+		Muted:   req.Muted,
+	})
+	if err == service.ErrNotFound {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("conversation not found"))
+		return
+	}
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h *ConversationHandler) DoHistory(ctx kingim.Context) {
+	var req pkt.HistoryReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if req.GetDest() == "" {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("dest is required"))
+		return
+	}
+	resp, err := h.msgService.GetHistory(ctx.Context(), ctx.Session().GetApp(), &rpc.GetHistoryReq{
+		Account:   ctx.Session().GetAccount(),
+		Dest:      req.GetDest(),
+		Group:     req.GetGroup(),
+		MessageId: req.GetMessageId(),
+		Forward:   req.GetForward(),
+		Limit:     req.GetLimit(),
+	})
+	if err == service.ErrNotFound {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("message not found in this conversation"))
+		return
+	}
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	var messages = make([]*pkt.HistoryMessage, len(resp.List))
+	for i, val := range resp.List {
+		messages[i] = &pkt.HistoryMessage{
+			MessageId: val.MessageId,
+			Sender:    val.Sender,
+			SendTime:  val.SendTime,
+			Type:      val.Type,
+			Body:      val.Body,
+			Extra:     val.Extra,
+			Recalled:  val.Recalled,
+			EditedAt:  val.EditedAt,
+			Revision:  val.Revision,
+		}
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.HistoryResp{
+		Messages: messages,
+		HasMore:  resp.HasMore,
+	})
+}
+
+func (h *ConversationHandler) DoSearch(ctx kingim.Context) {
+	var req pkt.MessageSearchReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if req.GetKeyword() == "" {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("keyword is required"))
+		return
+	}
+	if req.GetPeer() != "" && req.GetGroup() != "" {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("peer and group can not be used together"))
+		return
+	}
+	resp, err := h.msgService.Search(ctx.Context(), ctx.Session().GetApp(), &rpc.SearchMessageReq{
+		Account:   ctx.Session().GetAccount(),
+		Keyword:   req.GetKeyword(),
+		Peer:      req.GetPeer(),
+		Group:     req.GetGroup(),
+		Types:     req.GetTypes(),
+		StartTime: req.GetStartTime(),
+		EndTime:   req.GetEndTime(),
+		Before:    req.GetBefore(),
+		Limit:     req.GetLimit(),
+	})
+	if err == service.ErrInvalid {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	var hits = make([]*pkt.SearchHit, len(resp.Hits))
+	for i, val := range resp.Hits {
+		hits[i] = &pkt.SearchHit{
+			MessageId: val.MessageId,
+			Sender:    val.Sender,
+			Dest:      val.Dest,
+			Group:     val.Group,
+			SendTime:  val.SendTime,
+			Type:      val.Type,
+			Body:      val.Body,
+			Extra:     val.Extra,
+		}
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.MessageSearchResp{
+		Hits:       hits,
+		NextCursor: resp.NextCursor,
+	})
+}
@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"errors"
+
+	"google.golang.org/protobuf/proto"
 	"kingim"
+	"kingim/logger"
 	"kingim/services/server/service"
+	"kingim/wire"
 	"kingim/wire/pkt"
 	"kingim/wire/rpc"
 )
@@ -22,11 +27,11 @@ func (h*GroupHandler) DoCreate(ctx kingim.Context) {
 		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
 		return
 	}
-	resp, err := h.groupService.Create(ctx.Session().GetApp(), &rpc.CreateGroupReq{
+	resp, err := h.groupService.Create(ctx.Context(), ctx.Session().GetApp(), &rpc.CreateGroupReq{
 		Name: req.GetName(),
 		Avatar: req.GetAvatar(),
 		Introduction: req.GetIntroduction(),
-		Owner: req.Owner,
+		Owner: ctx.Session().GetAccount(),   // 创建者就是群主
 		Members: req.Members,
 	})
 	if err != nil {
@@ -44,10 +49,472 @@ func (h*GroupHandler) DoCreate(ctx kingim.Context) {
 			GroupId: resp.GroupId,
 			Members: req.GetMembers(),
 		}, locs...); err != nil {
-			_= ctx.RespWithError(pkt.Status_SystemException, err)
-			return
+			logger.Warnf("notify members of group %s failed: %v", resp.GroupId, err)
+		}
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.GroupCreateResp{
+		GroupId: resp.GroupId,
+	})
+}
+
+// DoJoin account为空时表示自己申请加入，否则是邀请account加入
+func (h*GroupHandler) DoJoin(ctx kingim.Context) {
+	var req pkt.GroupJoinReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	account := req.GetAccount()
+	if account == "" {
+		account = ctx.Session().GetAccount()
+	}
+	resp, err := h.groupService.Join(ctx.Context(), ctx.Session().GetApp(), &rpc.JoinGroupReq{
+		Account: account,
+		GroupId: req.GetGroupId(),
+		Operator: ctx.Session().GetAccount(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	if resp.GetPending() {
+		// 需要审批时只通知群主和管理员
+		h.notifyAdmins(ctx, req.GetGroupId(), &pkt.GroupJoinRequestNotify{
+			RequestId: resp.GetRequestId(),
+			GroupId: req.GetGroupId(),
+			Account: account,
+			Inviter: inviter(account, ctx.Session().GetAccount()),
+		})
+	} else {
+		// 通知群中在线的成员
+		h.notifyMembers(ctx, req.GetGroupId(), &pkt.GroupJoinNotify{
+			GroupId: req.GetGroupId(),
+			Account: account,
+		})
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.GroupJoinResp{
+		Pending: resp.GetPending(),
+		RequestId: resp.GetRequestId(),
+	})
+}
+
+// DoQuit 只能退出自己，移除其它成员使用DoKick
+func (h*GroupHandler) DoQuit(ctx kingim.Context) {
+	var req pkt.GroupQuitReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	account := ctx.Session().GetAccount()
+	err := h.groupService.Quit(ctx.Context(), ctx.Session().GetApp(), &rpc.QuitGroupReq{
+		Account: account,
+		GroupId: req.GetGroupId(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	h.notifyMembers(ctx, req.GetGroupId(), &pkt.GroupQuitNotify{
+		GroupId: req.GetGroupId(),
+		Account: account,
+	})
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h*GroupHandler) DoKick(ctx kingim.Context) {
+	var req pkt.GroupKickReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	err := h.groupService.Kick(ctx.Context(), ctx.Session().GetApp(), &rpc.KickGroupMemberReq{
+		Operator: ctx.Session().GetAccount(),
+		GroupId: req.GetGroupId(),
+		Account: req.GetAccount(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	// 被移除的成员也会收到通知
+	h.notifyMembers(ctx, req.GetGroupId(), &pkt.GroupKickNotify{
+		GroupId: req.GetGroupId(),
+		Account: req.GetAccount(),
+		Operator: ctx.Session().GetAccount(),
+	}, req.GetAccount())
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h*GroupHandler) DoRole(ctx kingim.Context) {
+	var req pkt.GroupRoleReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	err := h.groupService.SetRole(ctx.Context(), ctx.Session().GetApp(), &rpc.SetGroupRoleReq{
+		Operator: ctx.Session().GetAccount(),
+		GroupId: req.GetGroupId(),
+		Account: req.GetAccount(),
+		Admin: req.GetAdmin(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	var role int32 = wire.GroupRoleMember
+	if req.GetAdmin() {
+		role = wire.GroupRoleAdmin
+	}
+	h.notifyMembers(ctx, req.GetGroupId(), &pkt.GroupRoleNotify{
+		GroupId: req.GetGroupId(),
+		Account: req.GetAccount(),
+		Role: role,
+	})
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h*GroupHandler) DoTransfer(ctx kingim.Context) {
+	var req pkt.GroupTransferReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	err := h.groupService.Transfer(ctx.Context(), ctx.Session().GetApp(), &rpc.TransferGroupReq{
+		Operator: ctx.Session().GetAccount(),
+		GroupId: req.GetGroupId(),
+		Account: req.GetAccount(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	h.notifyMembers(ctx, req.GetGroupId(), &pkt.GroupTransferNotify{
+		GroupId: req.GetGroupId(),
+		From: ctx.Session().GetAccount(),
+		To: req.GetAccount(),
+	})
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h*GroupHandler) DoJoinMode(ctx kingim.Context) {
+	var req pkt.GroupJoinModeReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	err := h.groupService.SetJoinMode(ctx.Context(), ctx.Session().GetApp(), &rpc.SetGroupJoinModeReq{
+		Operator: ctx.Session().GetAccount(),
+		GroupId: req.GetGroupId(),
+		JoinMode: req.GetJoinMode(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	h.notifyMembers(ctx, req.GetGroupId(), &pkt.GroupJoinModeNotify{
+		GroupId: req.GetGroupId(),
+		JoinMode: req.GetJoinMode(),
+	})
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h*GroupHandler) DoDissolve(ctx kingim.Context) {
+	var req pkt.GroupDissolveReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	// 解散之后成员列表为空，先读取需要通知的成员
+	members, err := h.members(ctx, req.GetGroupId())
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	err = h.groupService.Dissolve(ctx.Context(), ctx.Session().GetApp(), &rpc.DissolveGroupReq{
+		Operator: ctx.Session().GetAccount(),
+		GroupId: req.GetGroupId(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	notifyAccounts(ctx, accounts(members), &pkt.GroupDissolveNotify{
+		GroupId: req.GetGroupId(),
+		Operator: ctx.Session().GetAccount(),
+	})
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h*GroupHandler) DoMute(ctx kingim.Context) {
+	var req pkt.GroupMuteReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	err := h.groupService.Mute(ctx.Context(), ctx.Session().GetApp(), &rpc.MuteGroupReq{
+		Operator: ctx.Session().GetAccount(),
+		GroupId: req.GetGroupId(),
+		Muted: req.GetMuted(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	h.notifyMembers(ctx, req.GetGroupId(), &pkt.GroupMuteNotify{
+		GroupId: req.GetGroupId(),
+		Muted: req.GetMuted(),
+		Operator: ctx.Session().GetAccount(),
+	})
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h*GroupHandler) DoMemberMute(ctx kingim.Context) {
+	var req pkt.GroupMemberMuteReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	resp, err := h.groupService.MuteMember(ctx.Context(), ctx.Session().GetApp(), &rpc.MuteMemberReq{
+		Operator: ctx.Session().GetAccount(),
+		GroupId: req.GetGroupId(),
+		Account: req.GetAccount(),
+		Duration: req.GetDuration(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	h.notifyMembers(ctx, req.GetGroupId(), &pkt.GroupMemberMuteNotify{
+		GroupId: req.GetGroupId(),
+		Account: req.GetAccount(),
+		MutedUntil: resp.GetMutedUntil(),
+		Operator: ctx.Session().GetAccount(),
+	})
+	_ = ctx.Resp(pkt.Status_Success, &pkt.GroupMemberMuteResp{MutedUntil: resp.GetMutedUntil()})
+}
+
+func (h*GroupHandler) DoAnnouncement(ctx kingim.Context) {
+	var req pkt.GroupAnnouncementReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	resp, err := h.groupService.SetAnnouncement(ctx.Context(), ctx.Session().GetApp(), &rpc.SetAnnouncementReq{
+		Operator: ctx.Session().GetAccount(),
+		GroupId: req.GetGroupId(),
+		Announcement: req.GetAnnouncement(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	h.notifyMembers(ctx, req.GetGroupId(), &pkt.GroupAnnouncementNotify{
+		GroupId: req.GetGroupId(),
+		Announcement: req.GetAnnouncement(),
+		Operator: ctx.Session().GetAccount(),
+		UpdatedAt: resp.GetUpdatedAt(),
+	})
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h*GroupHandler) DoJoinRequests(ctx kingim.Context) {
+	var req pkt.GroupJoinRequestsReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	resp, err := h.groupService.JoinRequests(ctx.Context(), ctx.Session().GetApp(), &rpc.GroupJoinRequestsReq{
+		Operator: ctx.Session().GetAccount(),
+		GroupId: req.GetGroupId(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	var list = make([]*pkt.GroupJoinRequest, len(resp.List))
+	for i, val := range resp.List {
+		list[i] = &pkt.GroupJoinRequest{
+			Id: val.Id,
+			Account: val.Account,
+			Inviter: val.Inviter,
+			CreatedAt: val.CreatedAt,
+		}
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.GroupJoinRequestsResp{Requests: list})
+}
+
+// DoJoinHandle 审批入群申请，结果推送给申请人，通过时再通知群成员
+func (h*GroupHandler) DoJoinHandle(ctx kingim.Context) {
+	var req pkt.GroupJoinHandleReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	resp, err := h.groupService.HandleJoin(ctx.Context(), ctx.Session().GetApp(), &rpc.HandleGroupJoinReq{
+		Operator: ctx.Session().GetAccount(),
+		RequestId: req.GetRequestId(),
+		Approve: req.GetApprove(),
+	})
+	if err != nil {
+		respWithGroupError(ctx, err)
+		return
+	}
+	notifyAccount(ctx, resp.GetAccount(), &pkt.GroupJoinHandledNotify{
+		RequestId: req.GetRequestId(),
+		GroupId: resp.GetGroupId(),
+		Approved: req.GetApprove(),
+	})
+	if req.GetApprove() {
+		h.notifyMembers(ctx, resp.GetGroupId(), &pkt.GroupJoinNotify{
+			GroupId: resp.GetGroupId(),
+			Account: resp.GetAccount(),
+		})
+	}
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+func (h*GroupHandler) DoDetail(ctx kingim.Context) {
+	var req pkt.GroupGetReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	resp, err := h.groupService.Detail(ctx.Context(), ctx.Session().GetApp(), &rpc.GetGroupReq{
+		GroupId: req.GetGroupId(),
+	})
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	membersResp, err := h.groupService.Members(ctx.Context(), ctx.Session().GetApp(), &rpc.GroupMembersReq{
+		GroupId: req.GetGroupId(),
+	})
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.GroupGetResp{
+		Id: resp.Id,
+		Name: resp.Name,
+		Avatar: resp.Avatar,
+		Introduction: resp.Introduction,
+		Owner: resp.Owner,
+		Members: toMembers(membersResp.Users),
+		CreatedAt: resp.CreatedAt,
+		JoinMode: resp.JoinMode,
+		Muted: resp.Muted,
+		Announcement: resp.Announcement,
+		AnnouncementBy: resp.AnnouncementBy,
+		AnnouncementAt: resp.AnnouncementAt,
+	})
+}
+
+func (h*GroupHandler) DoMembers(ctx kingim.Context) {
+	var req pkt.GroupMembersReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	resp, err := h.groupService.Members(ctx.Context(), ctx.Session().GetApp(), &rpc.GroupMembersReq{
+		GroupId: req.GetGroupId(),
+	})
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.GroupMembersResp{
+		Members: toMembers(resp.Users),
+	})
+}
+
+func (h*GroupHandler) members(ctx kingim.Context, group string) ([]*rpc.Member, error) {
+	resp, err := h.groupService.Members(ctx.Context(), ctx.Session().GetApp(), &rpc.GroupMembersReq{
+		GroupId: group,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Users, nil
+}
+
+// 给群中在线的成员推送一条通知，extra是不在群中但是也需要通知的账号
+func (h*GroupHandler) notifyMembers(ctx kingim.Context, group string, body proto.Message, extra ...string) {
+	members, err := h.members(ctx, group)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	notifyAccounts(ctx, append(accounts(members), extra...), body)
+}
+
+// notifyAdmins 只通知群主和管理员
+func (h*GroupHandler) notifyAdmins(ctx kingim.Context, group string, body proto.Message) {
+	members, err := h.members(ctx, group)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	var admins []string
+	for _, m := range members {
+		if m.Role >= wire.GroupRoleAdmin {
+			admins = append(admins, m.Account)
 		}
 	}
+	notifyAccounts(ctx, admins, body)
+}
+
+func notifyAccounts(ctx kingim.Context, accounts []string, body proto.Message) {
+	if len(accounts) == 0 {
+		return
+	}
+	locs, err := ctx.GetLocations(accounts...)
+	if err != nil {
+		return
+	}
+	_ = ctx.Dispatch(body, locs...)
+}
 
+func accounts(members []*rpc.Member) []string {
+	var list = make([]string, len(members))
+	for i, m := range members {
+		list[i] = m.Account
+	}
+	return list
+}
 
+// inviter 自己申请时没有邀请人
+func inviter(account, operator string) string {
+	if account == operator {
+		return ""
+	}
+	return operator
+}
+
+// respWithGroupError 把royal返回的错误转换为响应的状态码
+func respWithGroupError(ctx kingim.Context, err error) {
+	switch err {
+	case service.ErrForbidden:
+		_ = ctx.RespWithError(pkt.Status_Unauthorized, errors.New("permission denied"))
+	case service.ErrNotFound:
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("group or member not found"))
+	case service.ErrInvalid:
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+	default:
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+	}
+}
+
+func toMembers(users []*rpc.Member) []*pkt.Member {
+	var members = make([]*pkt.Member, len(users))
+	for i, user := range users {
+		members[i] = &pkt.Member{
+			Account: user.Account,
+			Alias: user.Alias,
+			Avatar: user.Avatar,
+			JoinTime: user.JoinTime,
+			Role: user.Role,
+			MutedUntil: user.MutedUntil,
+		}
+	}
+	return members
 }
\ No newline at end of file
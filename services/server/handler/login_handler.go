@@ -1,17 +1,53 @@
 package handler
 
 import (
+	"fmt"
 	"kingim"
 	"kingim/logger"
 	"kingim/wire/pkt"
+	"sort"
 )
 
-type LoginHandler struct {
+// KickPolicy 同一个账号再次登录时的踢人策略
+type KickPolicy string
+
+const (
+	// KickSameDevice 只踢掉同一设备上的旧连接
+	KickSameDevice KickPolicy = "same_device"
+	// KickAll 踢掉这个账号所有设备上的连接，同一时间只有一个设备在线
+	KickAll KickPolicy = "all"
+	// KickExceed 踢掉同一设备上的旧连接，在线设备超过MaxDevices时踢掉最早登录的设备
+	KickExceed KickPolicy = "exceed"
+)
 
+// LoginOptions LoginHandler 的配置
+type LoginOptions struct {
+	Policy KickPolicy
+	// MaxDevices 同时在线的最大设备数，只在KickExceed策略下使用
+	MaxDevices int
+	// Presence 账号上线和下线时通知订阅者，为nil时不通知
+	Presence *PresenceHandler
+	// Rooms 连接下线或者被踢时离开加入的房间，为nil时不处理
+	Rooms kingim.RoomStorage
 }
 
-func NewLoginHandler() *LoginHandler {
-	return &LoginHandler{}
+type LoginHandler struct {
+	options LoginOptions
+}
+
+func NewLoginHandler(opts LoginOptions) (*LoginHandler, error) {
+	switch opts.Policy {
+	case "":
+		opts.Policy = KickSameDevice
+	case KickSameDevice, KickAll:
+	case KickExceed:
+		if opts.MaxDevices <= 0 {
+			return nil, fmt.Errorf("invalid max devices %d of policy %s", opts.MaxDevices, opts.Policy)
+		}
+	default:
+		return nil, fmt.Errorf("unknown kick policy %s", opts.Policy)
+	}
+	return &LoginHandler{options: opts}, nil
 }
 
 func (h *LoginHandler) DoSysLogin(ctx kingim.Context) {
@@ -23,21 +59,29 @@ func (h *LoginHandler) DoSysLogin(ctx kingim.Context) {
 	}
 	logger.Infof("do login of %v", session.String())
 	// 判断用户是否已登录
-	old,err := ctx.GetLocation(session.Account,"")
+	olds,err := ctx.GetLocations(session.Account)
 	if err != nil && err != kingim.ErrSessionNil {
 		_ = ctx.RespWithError(pkt.Status_SystemException, err)
 		return
 	}
-	if old != nil {
+	for _, old := range h.kickouts(&session, olds) {
 		_ = ctx.Dispatch(&pkt.KickoutNotify{
 			ChannelId: old.ChannelId,
 		},old)
+		if err = ctx.Delete(session.Account, old.ChannelId); err != nil {
+			logger.Warnf("delete session %s of %s failed: %v", old.ChannelId, session.Account, err)
+		}
+		h.leaveRooms(old.GateId, old.ChannelId)
 	}
 	err = ctx.Add(&session)
 	if err != nil {
 		_ = ctx.RespWithError(pkt.Status_SystemException, err)
 		return
 	}
+	// 第一个设备登录时账号才从离线变为在线
+	if h.options.Presence != nil {
+		h.options.Presence.Online(session.App, session.Account, len(olds) == 0)
+	}
 	var resp = &pkt.LoginResp{
 		ChannelId: session.ChannelId,
 		Account: session.Account,
@@ -45,6 +89,58 @@ func (h *LoginHandler) DoSysLogin(ctx kingim.Context) {
 	_ = ctx.Resp(pkt.Status_Success,resp)
 }
 
+// kickouts 根据踢人策略返回需要被踢下线的连接
+func (h *LoginHandler) kickouts(session *pkt.Session, olds []*kingim.Location) []*kingim.Location {
+	if h.options.Policy == KickAll {
+		return olds
+	}
+	var kicks []*kingim.Location
+	var others []*kingim.Location
+	for _, old := range olds {
+		if old.Device == session.Device {
+			kicks = append(kicks, old)
+		} else {
+			others = append(others, old)
+		}
+	}
+	if h.options.Policy == KickExceed && len(others) >= h.options.MaxDevices {
+		// 按登录时间排序，踢掉最早登录的设备，给本次登录留出一个位置
+		sort.Slice(others, func(i, j int) bool {
+			return others[i].LoginAt < others[j].LoginAt
+		})
+		kicks = append(kicks, others[:len(others)-h.options.MaxDevices+1]...)
+	}
+	return kicks
+}
+
+// DoSysRefresh 为网关上报的活跃连接和它们加入的房间续期，这是网关发出的内部请求，不需要响应。
+// 只为发出请求的网关上的连接续期，其它网关的连接按已经过期统计
+func (h*LoginHandler) DoSysRefresh(ctx kingim.Context) {
+	var req pkt.SessionRefreshReq
+	if err := ctx.ReadBody(&req); err != nil {
+		logger.WithField("func", "DoSysRefresh").Warn(err)
+		return
+	}
+	var expired int
+	refreshed := make([]string, 0, len(req.GetChannelIds()))
+	for _, id := range req.GetChannelIds() {
+		err := ctx.Refresh(ctx.Session().GetGateId(), id)
+		if err == kingim.ErrSessionNil {
+			expired++
+		} else if err != nil {
+			logger.WithField("func", "DoSysRefresh").Warnf("refresh session %s failed: %v", id, err)
+		} else {
+			refreshed = append(refreshed, id)
+		}
+	}
+	if h.options.Rooms != nil && len(refreshed) > 0 {
+		if err := h.options.Rooms.Refresh(ctx.Session().GetGateId(), refreshed...); err != nil {
+			logger.WithField("func", "DoSysRefresh").Warnf("refresh rooms failed: %v", err)
+		}
+	}
+	logger.WithField("func", "DoSysRefresh").Debugf("refresh %d sessions from %s, %d expired", len(req.GetChannelIds()), ctx.Session().GetGateId(), expired)
+}
+
 func (h*LoginHandler) DoSysLogout(ctx kingim.Context) {
 	logger.WithField("func", "DoSysLogout").Infof("do Logout of %s %s ", ctx.Session().GetChannelId(), ctx.Session().GetAccount())
 	err := ctx.Delete(ctx.Session().GetAccount(),ctx.Session().GetChannelId())
@@ -52,5 +148,22 @@ func (h*LoginHandler) DoSysLogout(ctx kingim.Context) {
 		_ = ctx.RespWithError(pkt.Status_SystemException, err)
 		return
 	}
+	h.leaveRooms(ctx.Session().GetGateId(), ctx.Session().GetChannelId())
+	// 最后一个设备下线之后账号才是离线状态
+	if h.options.Presence != nil {
+		if _, err = ctx.GetLocations(ctx.Session().GetAccount()); err == kingim.ErrSessionNil {
+			h.options.Presence.Offline(ctx.Session().GetApp(), ctx.Session().GetAccount())
+		}
+	}
 	_ = ctx.Resp(pkt.Status_Success, nil)
-}
\ No newline at end of file
+}
+
+// leaveRooms 网关在连接断开时自己清理本地的房间成员，这里只清理RoomStorage
+func (h *LoginHandler) leaveRooms(gateId, channelId string) {
+	if h.options.Rooms == nil {
+		return
+	}
+	if _, err := h.options.Rooms.LeaveAll(gateId, channelId); err != nil {
+		logger.Warnf("leave rooms of %s failed: %v", channelId, err)
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"kingim"
+	"kingim/storage"
+	"kingim/wire"
+	"kingim/wire/pkt"
+)
+
+func login(t *testing.T, r *kingim.Router, dispather kingim.Dispather, cache kingim.SessionStorage, session *pkt.Session) {
+	packet := pkt.New(wire.CommandLoginSignIn, pkt.WithChannel(session.ChannelId))
+	packet.WriteBody(session)
+	err := r.Serve(packet, dispather, cache, &pkt.Session{ChannelId: session.ChannelId, GateId: session.GateId})
+	assert.Nil(t, err)
+}
+
+func TestLoginHandler_DoSysLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cache := storage.NewMemoryStorage(0)
+	dispather := kingim.NewMockDispather(ctrl)
+	// 登录响应
+	dispather.EXPECT().Push("gate1", []string{"ch1"}, gomock.Any()).Return(nil)
+	dispather.EXPECT().Push("gate1", []string{"ch2"}, gomock.Any()).Return(nil)
+	dispather.EXPECT().Push("gate2", []string{"ch3"}, gomock.Any()).Return(nil)
+	// 同一设备再次登录，踢掉ch1
+	dispather.EXPECT().Push("gate1", []string{"ch1"}, gomock.Any()).Return(nil)
+
+	h, _ := NewLoginHandler(LoginOptions{})
+	r := kingim.NewRouter()
+	r.Handle(wire.CommandLoginSignIn, h.DoSysLogin)
+
+	login(t, r, dispather, cache, &pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"})
+	login(t, r, dispather, cache, &pkt.Session{Account: "test1", ChannelId: "ch2", GateId: "gate1", Device: "pc"})
+	login(t, r, dispather, cache, &pkt.Session{Account: "test1", ChannelId: "ch3", GateId: "gate2", Device: "phone"})
+
+	locs, err := cache.GetLocations("test1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(locs))
+	_, err = cache.Get("ch1")
+	assert.Equal(t, kingim.ErrSessionNil, err)
+}
+
+func TestLoginHandler_kickouts(t *testing.T) {
+	olds := []*kingim.Location{
+		{ChannelId: "ch1", Device: "phone", LoginAt: 3},
+		{ChannelId: "ch2", Device: "pc", LoginAt: 1},
+		{ChannelId: "ch3", Device: "pad", LoginAt: 2},
+	}
+	session := &pkt.Session{Account: "test1", ChannelId: "ch4", Device: "phone"}
+
+	h, err := NewLoginHandler(LoginOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []*kingim.Location{olds[0]}, h.kickouts(session, olds))
+
+	h, _ = NewLoginHandler(LoginOptions{Policy: KickAll})
+	assert.Equal(t, olds, h.kickouts(session, olds))
+
+	// 除了同一设备外还有pc和pad在线，最多两个设备时踢掉最早登录的pc
+	h, _ = NewLoginHandler(LoginOptions{Policy: KickExceed, MaxDevices: 2})
+	assert.Equal(t, []*kingim.Location{olds[0], olds[1]}, h.kickouts(session, olds))
+
+	h, _ = NewLoginHandler(LoginOptions{Policy: KickExceed, MaxDevices: 3})
+	assert.Equal(t, []*kingim.Location{olds[0]}, h.kickouts(session, olds))
+
+	_, err = NewLoginHandler(LoginOptions{Policy: KickExceed})
+	assert.NotNil(t, err)
+	_, err = NewLoginHandler(LoginOptions{Policy: "unknown"})
+	assert.NotNil(t, err)
+}
+
+func TestLoginHandler_DoSysRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cache := kingim.NewMockSessionStorage(ctrl)
+	dispather := kingim.NewMockDispather(ctrl)
+	// 只为发出请求的网关续期
+	cache.EXPECT().Refresh("gate1", "ch1").Return(nil)
+	cache.EXPECT().Refresh("gate1", "ch3").Return(kingim.ErrSessionNil)
+
+	rooms := &refreshRooms{}
+	h, _ := NewLoginHandler(LoginOptions{Rooms: rooms})
+	r := kingim.NewRouter()
+	r.Handle(wire.CommandLoginRefresh, h.DoSysRefresh)
+
+	packet := pkt.New(wire.CommandLoginRefresh, pkt.WithChannel("gate1"))
+	packet.WriteBody(&pkt.SessionRefreshReq{ChannelIds: []string{"ch1", "ch3"}})
+	err := r.Serve(packet, dispather, cache, &pkt.Session{ChannelId: "gate1", GateId: "gate1"})
+	assert.Nil(t, err)
+	// 只为续期成功的连接续期房间
+	assert.Equal(t, []string{"ch1"}, rooms.channels)
+}
+
+// refreshRooms 记录续期的channels
+type refreshRooms struct {
+	kingim.RoomStorage
+	channels []string
+}
+
+func (r *refreshRooms) Refresh(gateId string, channelIds ...string) error {
+	r.channels = append(r.channels, channelIds...)
+	return nil
+}
@@ -22,7 +22,7 @@ func (h*OfflineHandler) DoSyncIndex(ctx kingim.Context) {
 		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
 		return
 	}
-	resp, err := h.msgService.GetMessageIndex(ctx.Session().GetApp(), &rpc.GetOfflineMessageIndexReq{
+	resp, err := h.msgService.GetMessageIndex(ctx.Context(), ctx.Session().GetApp(), &rpc.GetOfflineMessageIndexReq{
 		Account: ctx.Session().GetAccount(),
 		MessageId: req.GetMessageId(),
 	})
@@ -40,8 +40,18 @@ func (h*OfflineHandler) DoSyncIndex(ctx kingim.Context) {
 			Group: val.Group,
 		}
 	}
+	var unreads = make([]*pkt.UnreadCount, len(resp.Unreads))
+	for i, val := range resp.Unreads {
+		unreads[i] = &pkt.UnreadCount{
+			Dest: val.Dest,
+			Group: val.Group,
+			Count: val.Count,
+			ReadMessageId: val.ReadMessageId,
+		}
+	}
 	_ = ctx.Resp(pkt.Status_Success, &pkt.MessageIndexResp{
 		Indexes: list,
+		Unreads: unreads,
 	})
 }
 
@@ -55,7 +65,7 @@ func (h*OfflineHandler) DoSyncContent (ctx kingim.Context) {
 		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("messageIds is empty"))
 		return
 	}
-	resp,err := h.msgService.GetMessageContent(ctx.Session().GetApp(), &rpc.GetOfflineMessageContentReq{
+	resp,err := h.msgService.GetMessageContent(ctx.Context(), ctx.Session().GetApp(), &rpc.GetOfflineMessageContentReq{
 		MessageIds: req.MessageIds,
 	})
 	if err != nil {
@@ -69,6 +79,9 @@ func (h*OfflineHandler) DoSyncContent (ctx kingim.Context) {
 			Type: val.Type,
 			Body: val.Body,
 			Extra: val.Extra,
+			Recalled: val.Recalled,
+			EditedAt: val.EditedAt,
+			Revision: val.Revision,
 		}
 	}
 	_ = ctx.Resp(pkt.Status_Success, &pkt.MessageContentResp{Contents: list})
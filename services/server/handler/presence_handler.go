@@ -0,0 +1,356 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"kingim"
+	"kingim/logger"
+	"kingim/wire"
+	"kingim/wire/pkt"
+)
+
+// MaxPresenceAccounts 一次查询或者订阅的最大账号数
+const MaxPresenceAccounts = 500
+
+const (
+	// presenceCheckDelay 登录之后多久检查一次账号是否还在线，会话过期而没有登出时由检查发出离线通知
+	presenceCheckDelay = time.Minute
+	// presenceCheckBatch 每次取出的需要检查的账号数
+	presenceCheckBatch = 100
+	// presenceQueueSize 等待通知的状态变化数，超过之后丢弃新的通知
+	presenceQueueSize = 10000
+	// presenceNotifyTimeout 一次通知中检查订阅者关系的超时时间
+	presenceNotifyTimeout = time.Second * 5
+)
+
+type presenceChange struct {
+	app     string
+	account string
+}
+
+// PresenceHandler 状态的变化由后台按顺序通知订阅者，登录和登出不需要等待关系检查
+type PresenceHandler struct {
+	dispather kingim.Dispather
+	sessions  kingim.SessionStorage
+	store     kingim.PresenceStorage
+	talk      TalkOptions
+	changes   chan presenceChange
+	// pending 还没有通知完成的变化
+	pending sync.WaitGroup
+	closed  *kingim.Event
+}
+
+func NewPresenceHandler(dispather kingim.Dispather, sessions kingim.SessionStorage, store kingim.PresenceStorage) *PresenceHandler {
+	h := &PresenceHandler{
+		dispather: dispather,
+		sessions:  sessions,
+		store:     store,
+		talk:      TalkOptions{Policy: TalkAnyone},
+		changes:   make(chan presenceChange, presenceQueueSize),
+		closed:    kingim.NewEvent(),
+	}
+	go h.loop()
+	return h
+}
+
+// SetTalkOptions 只有可以给对方发消息的账号才能查询和订阅对方的状态，默认不检查
+func (h *PresenceHandler) SetTalkOptions(opts TalkOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	h.talk = opts
+	return nil
+}
+
+func (h *PresenceHandler) DoQuery(ctx kingim.Context) {
+	var req pkt.PresenceQueryReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if err := checkAccounts(req.GetAccounts()); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	accounts, err := h.visible(ctx, req.GetAccounts())
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	presences, err := h.query(ctx, ctx.Session().GetApp(), accounts...)
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.PresenceResp{Presences: presences})
+}
+
+// DoSubscribe 订阅之后返回这些账号当前的状态，之后的变化通过PresenceNotify推送，
+// 没有权限查看的账号不会被订阅，也不在返回的列表中
+func (h *PresenceHandler) DoSubscribe(ctx kingim.Context) {
+	var req pkt.PresenceSubscribeReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if err := checkAccounts(req.GetAccounts()); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	app := ctx.Session().GetApp()
+	account := ctx.Session().GetAccount()
+	if req.GetUnsubscribe() {
+		if err := h.store.Unsubscribe(app, account, req.GetAccounts()...); err != nil {
+			_ = ctx.RespWithError(pkt.Status_SystemException, err)
+			return
+		}
+		_ = ctx.Resp(pkt.Status_Success, nil)
+		return
+	}
+	accounts, err := h.visible(ctx, req.GetAccounts())
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	if len(accounts) > 0 {
+		if err = h.store.Subscribe(app, account, accounts...); err != nil {
+			_ = ctx.RespWithError(pkt.Status_SystemException, err)
+			return
+		}
+	}
+	presences, err := h.query(ctx, app, accounts...)
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.PresenceResp{Presences: presences})
+}
+
+// DoStatus 设置自定义状态，空字符串表示清除
+func (h *PresenceHandler) DoStatus(ctx kingim.Context) {
+	var req pkt.PresenceStatusReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if len(req.GetStatus()) > 32 {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, errors.New("status is too long"))
+		return
+	}
+	app := ctx.Session().GetApp()
+	account := ctx.Session().GetAccount()
+	if err := h.store.SetStatus(app, account, req.GetStatus()); err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	h.Notify(app, account)
+	_ = ctx.Resp(pkt.Status_Success, nil)
+}
+
+// Online 账号登录之后安排一次离线检查，first为true时表示账号从离线变为在线，通知订阅者
+func (h *PresenceHandler) Online(app string, account string, first bool) {
+	if err := h.store.SetOnline(app, account, millis(time.Now().Add(presenceCheckDelay))); err != nil {
+		logger.Warnf("set online of %s failed: %v", account, err)
+	}
+	if first {
+		h.Notify(app, account)
+	}
+}
+
+// Offline 账号最后一个设备下线时记录最后在线时间并通知订阅者，
+// 登出、网关宕机时清理会话和会话过期时都会调用
+func (h *PresenceHandler) Offline(app string, account string) {
+	if err := h.store.SetLastSeen(app, account, millis(time.Now())); err != nil {
+		logger.Warnf("set last seen of %s failed: %v", account, err)
+	}
+	if err := h.store.RemoveOnline(app, account); err != nil {
+		logger.Warnf("remove online of %s failed: %v", account, err)
+	}
+	h.Notify(app, account)
+}
+
+// Notify 把account当前的状态异步推送给订阅者在线的设备，队列满时丢弃
+func (h *PresenceHandler) Notify(app string, account string) {
+	h.pending.Add(1)
+	select {
+	case h.changes <- presenceChange{app: app, account: account}:
+	default:
+		h.pending.Done()
+		logger.Warnf("too many presence changes, notify of %s is dropped", account)
+	}
+}
+
+func (h *PresenceHandler) Close() {
+	h.closed.Fire()
+}
+
+func (h *PresenceHandler) loop() {
+	ticker := time.NewTicker(presenceCheckDelay / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case change := <-h.changes:
+			h.notify(change.app, change.account)
+			h.pending.Done()
+		case <-ticker.C:
+			h.checkExpired(time.Now())
+		case <-h.closed.Done():
+			return
+		}
+	}
+}
+
+// notify 订阅之后关系发生了变化的订阅者不再通知
+func (h *PresenceHandler) notify(app string, account string) {
+	subscribers, err := h.store.Subscribers(app, account)
+	if err != nil {
+		logger.Warnf("get subscribers of %s failed: %v", account, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), presenceNotifyTimeout)
+	defer cancel()
+	allowed := subscribers[:0]
+	for _, subscriber := range subscribers {
+		ok, err := h.talk.allowed(ctx, app, subscriber, account)
+		if err != nil {
+			logger.Warnf("check relation of %s and %s failed: %v", subscriber, account, err)
+			continue
+		}
+		if ok {
+			allowed = append(allowed, subscriber)
+		}
+	}
+	if len(allowed) == 0 {
+		return
+	}
+	locs, err := h.sessions.GetLocations(allowed...)
+	if err != nil {
+		return
+	}
+	presences, err := h.query(h.sessions, app, account)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	packet := pkt.New(wire.CommandPresenceNotify, pkt.WithDest(account))
+	packet.Flag = pkt.Flag_Push
+	packet.WriteBody(&pkt.PresenceNotify{Presence: presences[0]})
+	if err = kingim.Dispatch(h.dispather, packet, inApp(locs, app)...); err != nil {
+		logger.Warnf("notify presence of %s failed: %v", account, err)
+	}
+}
+
+// checkExpired 检查到期的账号，还有在线的设备时按设备的过期时间安排下一次检查，否则发出离线通知
+func (h *PresenceHandler) checkExpired(now time.Time) {
+	for {
+		accounts, err := h.store.ClaimExpired(millis(now), presenceCheckBatch)
+		if err != nil {
+			logger.Warn(err)
+		}
+		for _, acc := range accounts {
+			h.check(acc.App, acc.Account, now)
+		}
+		if err != nil || len(accounts) < presenceCheckBatch {
+			return
+		}
+	}
+}
+
+func (h *PresenceHandler) check(app string, account string, now time.Time) {
+	locs, err := h.sessions.GetLocations(account)
+	if err != nil && err != kingim.ErrSessionNil {
+		logger.Warnf("check presence of %s failed: %v", account, err)
+		// 查询失败时稍后再检查
+		_ = h.store.SetOnline(app, account, millis(now.Add(presenceCheckDelay)))
+		return
+	}
+	locs = inApp(locs, app)
+	if len(locs) == 0 {
+		h.Offline(app, account)
+		return
+	}
+	checkAt := millis(now.Add(presenceCheckDelay))
+	for _, loc := range locs {
+		if loc.ExpireAt > checkAt {
+			checkAt = loc.ExpireAt
+		}
+	}
+	if err = h.store.SetOnline(app, account, checkAt); err != nil {
+		logger.Warnf("set online of %s failed: %v", account, err)
+	}
+}
+
+// visible 按单聊的关系检查过滤掉当前账号不能查看状态的账号
+func (h *PresenceHandler) visible(ctx kingim.Context, accounts []string) ([]string, error) {
+	app := ctx.Session().GetApp()
+	account := ctx.Session().GetAccount()
+	list := make([]string, 0, len(accounts))
+	for _, peer := range accounts {
+		if peer != account {
+			ok, err := h.talk.allowed(ctx.Context(), app, account, peer)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		list = append(list, peer)
+	}
+	return list, nil
+}
+
+// query 是否在线由会话中app的位置信息决定，自定义状态和最后在线时间从PresenceStorage中读取
+func (h *PresenceHandler) query(sessions kingim.SessionStorage, app string, accounts ...string) ([]*pkt.Presence, error) {
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+	locs, err := sessions.GetLocations(accounts...)
+	if err != nil && err != kingim.ErrSessionNil {
+		return nil, err
+	}
+	devices := make(map[string][]string)
+	for _, loc := range inApp(locs, app) {
+		devices[loc.Account] = append(devices[loc.Account], loc.Device)
+	}
+	stored, err := h.store.GetPresences(app, accounts...)
+	if err != nil {
+		return nil, err
+	}
+	presences := make([]*pkt.Presence, len(stored))
+	for i, p := range stored {
+		presences[i] = &pkt.Presence{
+			Account:  p.Account,
+			Online:   len(devices[p.Account]) > 0,
+			Status:   p.Status,
+			LastSeen: p.LastSeen,
+			Devices:  devices[p.Account],
+		}
+	}
+	return presences, nil
+}
+
+// inApp 只保留登录了app的位置信息
+func inApp(locs []*kingim.Location, app string) []*kingim.Location {
+	list := make([]*kingim.Location, 0, len(locs))
+	for _, loc := range locs {
+		if loc.App == app {
+			list = append(list, loc)
+		}
+	}
+	return list
+}
+
+func checkAccounts(accounts []string) error {
+	if len(accounts) == 0 {
+		return errors.New("accounts is empty")
+	}
+	if len(accounts) > MaxPresenceAccounts {
+		return fmt.Errorf("too many accounts, max is %d", MaxPresenceAccounts)
+	}
+	return nil
+}
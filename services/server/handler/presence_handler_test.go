@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"kingim"
+	"kingim/storage"
+	"kingim/wire"
+	"kingim/wire/pkt"
+)
+
+func TestPresenceHandler(t *testing.T) {
+	cache := storage.NewMemoryStorage(0)
+	dispather := &recordDispather{}
+	presence := NewPresenceHandler(dispather, cache, storage.NewMemoryPresenceStorage())
+	defer presence.Close()
+	h, _ := NewLoginHandler(LoginOptions{Presence: presence})
+	r := kingim.NewRouter()
+	r.Handle(wire.CommandLoginSignIn, h.DoSysLogin)
+	r.Handle(wire.CommandLoginSignOut, h.DoSysLogout)
+	r.Handle(wire.CommandPresenceSubscribe, presence.DoSubscribe)
+
+	login(t, r, dispather, cache, &pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"})
+	presence.pending.Wait()
+	// test1 订阅 test2
+	packet := pkt.New(wire.CommandPresenceSubscribe, pkt.WithChannel("ch1"))
+	packet.WriteBody(&pkt.PresenceSubscribeReq{Accounts: []string{"test2"}})
+	assert.Nil(t, r.Serve(packet, dispather, cache, &pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1"}))
+	var resp pkt.PresenceResp
+	assert.Nil(t, dispather.pushed[1].ReadBody(&resp))
+	assert.False(t, resp.Presences[0].Online)
+
+	// test2 的第一个设备登录时通知，第二个设备登录时不通知
+	dispather.pushed = nil
+	login(t, r, dispather, cache, &pkt.Session{Account: "test2", ChannelId: "ch2", GateId: "gate1", Device: "phone"})
+	// 通知在后台发出，不阻塞登录
+	presence.pending.Wait()
+	login(t, r, dispather, cache, &pkt.Session{Account: "test2", ChannelId: "ch3", GateId: "gate1", Device: "pc"})
+	presence.pending.Wait()
+	notifies := presenceNotifies(t, dispather)
+	assert.Equal(t, 1, len(notifies))
+	assert.True(t, notifies[0].Online)
+	assert.Equal(t, []string{"phone"}, notifies[0].Devices)
+
+	// 最后一个设备下线时通知
+	dispather.pushed = nil
+	logout := func(account, channel string) {
+		packet := pkt.New(wire.CommandLoginSignOut, pkt.WithChannel(channel))
+		assert.Nil(t, r.Serve(packet, dispather, cache, &pkt.Session{Account: account, ChannelId: channel, GateId: "gate1"}))
+	}
+	logout("test2", "ch2")
+	presence.pending.Wait()
+	assert.Equal(t, 0, len(presenceNotifies(t, dispather)))
+	logout("test2", "ch3")
+	presence.pending.Wait()
+	notifies = presenceNotifies(t, dispather)
+	assert.Equal(t, 1, len(notifies))
+	assert.False(t, notifies[0].Online)
+	assert.NotZero(t, notifies[0].LastSeen)
+}
+
+func presenceNotifies(t *testing.T, d *recordDispather) []*pkt.Presence {
+	var list []*pkt.Presence
+	for _, p := range d.pushed {
+		if p.Command != wire.CommandPresenceNotify {
+			continue
+		}
+		var notify pkt.PresenceNotify
+		assert.Nil(t, p.ReadBody(&notify))
+		list = append(list, notify.Presence)
+	}
+	return list
+}
+
+func TestPresenceHandler_TalkPolicy(t *testing.T) {
+	cache := storage.NewMemoryStorage(0)
+	store := storage.NewMemoryPresenceStorage()
+	presence := NewPresenceHandler(&recordDispather{}, cache, store)
+	defer presence.Close()
+	err := presence.SetTalkOptions(TalkOptions{
+		Contact: fakeContact{friends: map[string]bool{"test1,test2": true}},
+		Apps:    map[string]TalkPolicy{"app1": TalkFriendsOnly},
+	})
+	assert.Nil(t, err)
+	r := kingim.NewRouter()
+	r.Handle(wire.CommandPresenceSubscribe, presence.DoSubscribe)
+	r.Handle(wire.CommandPresenceQuery, presence.DoQuery)
+	_ = cache.Add(&pkt.Session{Account: "test3", ChannelId: "ch3", GateId: "gate1", App: "app1"})
+
+	serve := func(app string, packet *pkt.LogicPkt) []*pkt.Presence {
+		dispather := &recordDispather{}
+		assert.Nil(t, r.Serve(packet, dispather, cache, &pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", App: app}))
+		var resp pkt.PresenceResp
+		assert.Nil(t, dispather.pushed[0].ReadBody(&resp))
+		return resp.Presences
+	}
+	accounts := func(presences []*pkt.Presence) []string {
+		var list []string
+		for _, p := range presences {
+			list = append(list, p.Account)
+		}
+		return list
+	}
+
+	// test1 和 test3 不是好友，不能订阅和查询test3的状态
+	packet := pkt.New(wire.CommandPresenceSubscribe, pkt.WithChannel("ch1"))
+	packet.WriteBody(&pkt.PresenceSubscribeReq{Accounts: []string{"test2", "test3"}})
+	assert.Equal(t, []string{"test2"}, accounts(serve("app1", packet)))
+	subs, _ := store.Subscribers("app1", "test3")
+	assert.Empty(t, subs)
+	subs, _ = store.Subscribers("app1", "test2")
+	assert.Equal(t, []string{"test1"}, subs)
+
+	packet = pkt.New(wire.CommandPresenceQuery, pkt.WithChannel("ch1"))
+	packet.WriteBody(&pkt.PresenceQueryReq{Accounts: []string{"test1", "test3"}})
+	assert.Equal(t, []string{"test1"}, accounts(serve("app1", packet)))
+
+	// app2 没有配置关系检查，订阅关系和app1隔离
+	packet = pkt.New(wire.CommandPresenceQuery, pkt.WithChannel("ch1"))
+	packet.WriteBody(&pkt.PresenceQueryReq{Accounts: []string{"test3"}})
+	presences := serve("app2", packet)
+	assert.Equal(t, []string{"test3"}, accounts(presences))
+	subs, _ = store.Subscribers("app2", "test2")
+	assert.Empty(t, subs)
+}
+
+func TestPresenceHandler_Expired(t *testing.T) {
+	cache := storage.NewMemoryStorage(time.Millisecond * 50)
+	dispather := &recordDispather{}
+	store := storage.NewMemoryPresenceStorage()
+	presence := NewPresenceHandler(dispather, cache, store)
+	defer presence.Close()
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test1", ChannelId: "ch1", GateId: "gate1"})
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test2", ChannelId: "ch2", GateId: "gate1", Device: "phone"})
+	assert.Nil(t, store.Subscribe("app1", "test1", "test2"))
+	presence.Online("app1", "test2", false)
+
+	// 还有在线的设备时只安排下一次检查
+	now := time.Now().Add(presenceCheckDelay)
+	presence.checkExpired(now)
+	presence.pending.Wait()
+	assert.Empty(t, presenceNotifies(t, dispather))
+
+	// test2 的会话过期而没有登出，test1 续期之后还在线
+	time.Sleep(time.Millisecond * 30)
+	assert.Nil(t, cache.Refresh("gate1", "ch1"))
+	time.Sleep(time.Millisecond * 30)
+	presence.checkExpired(time.Now().Add(presenceCheckDelay * 2))
+	presence.pending.Wait()
+	notifies := presenceNotifies(t, dispather)
+	assert.Equal(t, 1, len(notifies))
+	assert.Equal(t, "test2", notifies[0].Account)
+	assert.False(t, notifies[0].Online)
+	accounts, _ := store.ClaimExpired(millis(time.Now().Add(time.Hour)), 10)
+	assert.Empty(t, accounts)
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"kingim"
+	"kingim/logger"
+	"kingim/wire"
+	"kingim/wire/pkt"
+)
+
+var (
+	ErrNoRoom    = errors.New("room is empty")
+	ErrNotInRoom = errors.New("not in the room")
+)
+
+// RoomHandler 房间的成员和消息都不保存。消息每个网关只推送一次，
+// 由网关推送给本地加入了这个房间的连接
+type RoomHandler struct {
+	store kingim.RoomStorage
+}
+
+func NewRoomHandler(store kingim.RoomStorage) *RoomHandler {
+	return &RoomHandler{store: store}
+}
+
+func (h *RoomHandler) DoJoin(ctx kingim.Context) {
+	room, ok := readRoom(ctx)
+	if !ok {
+		return
+	}
+	err := h.store.Join(room, ctx.Session().GetGateId(), ctx.Session().GetChannelId())
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	respRoom(ctx, room)
+}
+
+func (h *RoomHandler) DoLeave(ctx kingim.Context) {
+	room, ok := readRoom(ctx)
+	if !ok {
+		return
+	}
+	err := h.store.Leave(room, ctx.Session().GetGateId(), ctx.Session().GetChannelId())
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	respRoom(ctx, room)
+}
+
+// DoTalk 只有房间的成员可以发言，消息不保存，推送失败时直接丢弃
+func (h *RoomHandler) DoTalk(ctx kingim.Context) {
+	var req pkt.RoomTalkReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return
+	}
+	if req.GetRoom() == "" {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, ErrNoRoom)
+		return
+	}
+	room := roomKey(ctx.Session().GetApp(), req.GetRoom())
+	joined, err := h.store.Joined(room, ctx.Session().GetGateId(), ctx.Session().GetChannelId())
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	if !joined {
+		_ = ctx.RespWithError(pkt.Status_NotInRoom, ErrNotInRoom)
+		return
+	}
+	gateways, err := h.store.Gateways(room)
+	if err != nil {
+		_ = ctx.RespWithError(pkt.Status_SystemException, err)
+		return
+	}
+	sendTime := time.Now().UnixNano()
+	body := &pkt.RoomPush{
+		Room:     req.GetRoom(),
+		Sender:   ctx.Session().GetAccount(),
+		Type:     req.GetType(),
+		Body:     req.GetBody(),
+		Extra:    req.GetExtra(),
+		SendTime: sendTime,
+	}
+	for _, gateway := range gateways {
+		// 发送方的ChannelId不能发给其它成员，网关根据MetaSkipChannel跳过发送方
+		packet := pkt.New(wire.CommandRoomTalk, pkt.WithDest(req.GetRoom()))
+		packet.Flag = pkt.Flag_Push
+		packet.AddStringMeta(wire.MetaDestRoom, room)
+		packet.AddStringMeta(wire.MetaSkipChannel, ctx.Session().GetChannelId())
+		packet.WriteBody(body)
+		if err = ctx.Push(gateway, nil, packet); err != nil {
+			logger.Debugf("push room %s to %s failed: %v", room, gateway, err)
+		}
+	}
+	_ = ctx.Resp(pkt.Status_Success, &pkt.MessageResp{SendTime: sendTime})
+}
+
+func readRoom(ctx kingim.Context) (string, bool) {
+	var req pkt.RoomReq
+	if err := ctx.ReadBody(&req); err != nil {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, err)
+		return "", false
+	}
+	if req.GetRoom() == "" {
+		_ = ctx.RespWithError(pkt.Status_InvalidPacketBody, ErrNoRoom)
+		return "", false
+	}
+	return roomKey(ctx.Session().GetApp(), req.GetRoom()), true
+}
+
+// respRoom 加入和离开的响应中带上房间，网关收到之后更新本地的房间成员
+func respRoom(ctx kingim.Context, room string) {
+	packet := pkt.NewFrom(ctx.Header())
+	packet.Status = pkt.Status_Success
+	packet.Flag = pkt.Flag_Response
+	packet.AddStringMeta(wire.MetaDestRoom, room)
+	err := ctx.Push(ctx.Session().GetGateId(), []string{ctx.Session().GetChannelId()}, packet)
+	if err != nil {
+		logger.Warnf("resp %s of room %s failed: %v", ctx.Header().GetCommand(), room, err)
+	}
+}
+
+// roomKey 不同应用的房间互相隔离
+func roomKey(app, room string) string {
+	return fmt.Sprintf("%s:%s", app, room)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"kingim"
+	"kingim/storage"
+	"kingim/wire"
+	"kingim/wire/pkt"
+)
+
+func TestRoomHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cache := storage.NewMemoryStorage(0)
+	dispather := kingim.NewMockDispather(ctrl)
+	store := storage.NewMemoryRoomStorage()
+	h := NewRoomHandler(store)
+	r := kingim.NewRouter()
+	r.Handle(wire.CommandRoomJoin, h.DoJoin)
+	r.Handle(wire.CommandRoomTalk, h.DoTalk)
+
+	serve := func(command string, session *pkt.Session, body proto.Message) {
+		packet := pkt.New(command, pkt.WithChannel(session.ChannelId))
+		packet.WriteBody(body)
+		assert.Nil(t, r.Serve(packet, dispather, cache, session))
+	}
+	sessions := []*pkt.Session{
+		{App: "app1", Account: "test1", ChannelId: "ch1", GateId: "gate1"},
+		{App: "app1", Account: "test2", ChannelId: "ch2", GateId: "gate1"},
+		{App: "app1", Account: "test3", ChannelId: "ch3", GateId: "gate2"},
+	}
+	// 加入房间的响应中带上房间，网关据此更新本地的成员
+	for _, s := range sessions {
+		dispather.EXPECT().Push(s.GateId, []string{s.ChannelId}, gomock.Any()).
+			DoAndReturn(func(_ string, _ []string, p *pkt.LogicPkt) error {
+				room, ok := p.GetMeta(wire.MetaDestRoom)
+				assert.True(t, ok)
+				assert.Equal(t, "app1:room1", room)
+				assert.Equal(t, pkt.Status_Success, p.Status)
+				return nil
+			})
+		serve(wire.CommandRoomJoin, s, &pkt.RoomReq{Room: "room1"})
+	}
+
+	// 每个网关只推送一次
+	for _, gate := range []string{"gate1", "gate2"} {
+		dispather.EXPECT().Push(gate, gomock.Nil(), gomock.Any()).
+			DoAndReturn(func(_ string, _ []string, p *pkt.LogicPkt) error {
+				// 发送方的ChannelId不发给其它成员
+				assert.Empty(t, p.ChannelId)
+				skip, _ := p.GetMeta(wire.MetaSkipChannel)
+				assert.Equal(t, "ch1", skip)
+				var push pkt.RoomPush
+				assert.Nil(t, p.ReadBody(&push))
+				assert.Equal(t, "room1", push.Room)
+				assert.Equal(t, "test1", push.Sender)
+				assert.Equal(t, "hello", push.Body)
+				return nil
+			})
+	}
+	dispather.EXPECT().Push("gate1", []string{"ch1"}, gomock.Any()).Return(nil)
+	serve(wire.CommandRoomTalk, sessions[0], &pkt.RoomTalkReq{Room: "room1", Body: "hello"})
+
+	// 其它应用中同名的房间不是同一个房间
+	dispather.EXPECT().Push("gate1", []string{"ch4"}, gomock.Any()).
+		DoAndReturn(func(_ string, _ []string, p *pkt.LogicPkt) error {
+			assert.Equal(t, pkt.Status_NotInRoom, p.Status)
+			return nil
+		})
+	serve(wire.CommandRoomTalk, &pkt.Session{App: "app2", Account: "test4", ChannelId: "ch4", GateId: "gate1"}, &pkt.RoomTalkReq{Room: "room1", Body: "hello"})
+}
@@ -56,8 +56,8 @@ func (h*ServHandler) Receive(ag kingim.Agent, payload []byte) {
 		return
 	}
 	var session *pkt.Session
-	// 如果是登录则生成一个Session //否则在cache中读取一个
-	if packet.Command == wire.CommandLoginSignIn {
+	// 如果是登录或网关的续期请求则生成一个Session //否则在cache中读取一个
+	if packet.Command == wire.CommandLoginSignIn || packet.Command == wire.CommandLoginRefresh {
 		server ,_ := packet.GetMeta(wire.MetaDestServer)
 		session = &pkt.Session{
 			ChannelId: packet.ChannelId,  // 全局唯一ID
@@ -69,7 +69,7 @@ func (h*ServHandler) Receive(ag kingim.Agent, payload []byte) {
 		if err == kingim.ErrSessionNil {
 			_ = RespErr(ag, packet, pkt.Status_SessionNotFound)
 			return
-		}else {
+		}else if err != nil {
 			_ = RespErr(ag, packet, pkt.Status_SystemException)
 			return
 		}
@@ -81,6 +81,17 @@ func (h*ServHandler) Receive(ag kingim.Agent, payload []byte) {
 	}
 }
 
+// OrderKey 网关的所有客户端共用一个连接，因此按消息中客户端的channelId排序，
+// 保证同一个客户端的消息按发送的顺序处理
+func OrderKey(ag kingim.Agent, payload []byte) string {
+	// 只读取header中的channelId，消息在Receive中才完整地反序列化
+	id, err := pkt.ReadChannelId(payload)
+	if err != nil || id == "" {
+		return ag.ID()
+	}
+	return id
+}
+
 func RespErr(ag kingim.Agent, p *pkt.LogicPkt, status pkt.Status) error {
 	packet := pkt.NewFrom(&p.Header)
 	packet.Status = status
@@ -0,0 +1,226 @@
+package serv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kataras/iris/v12"
+	"kingim"
+	"kingim/services/server/service"
+	"kingim/wire"
+	"kingim/wire/pkt"
+	"kingim/wire/rpc"
+	"kingim/wire/token"
+)
+
+// MaxPushAccounts 一次推送的最大账号数
+const MaxPushAccounts = 1000
+
+var (
+	ErrPushTarget  = errors.New("exactly one of accounts, tags or all is required")
+	ErrPushMessage = errors.New("message is required")
+	ErrPushOffline = errors.New("offline requires accounts and sender")
+	ErrPushSecret  = errors.New("push secret is required")
+	ErrPushToken   = errors.New("invalid push token")
+	ErrPushSender  = errors.New("sender is not allowed")
+)
+
+// PushServer 后台服务调用的推送接口。通过SessionStorage找到在线的连接，
+// 按网关分组之后由container.Push推送，每个网关只推送一次，推送给所有连接时由网关在本地分发。
+// 调用方在Authorization中带上用secret签名的token，token中的app和账号限定了可以推送的应用和可以使用的发送方
+type PushServer struct {
+	secret    string
+	cache     kingim.SessionStorage
+	gateways  func() []string
+	message   service.Message
+	dispather kingim.Dispather
+}
+
+// NewPushServer gateways返回当前在线的网关，用于推送给所有在线的连接
+func NewPushServer(secret string, cache kingim.SessionStorage, gateways func() []string, message service.Message) (*PushServer, error) {
+	if secret == "" {
+		return nil, ErrPushSecret
+	}
+	return &PushServer{
+		secret:    secret,
+		cache:     cache,
+		gateways:  gateways,
+		message:   message,
+		dispather: &ServerDispather{},
+	}, nil
+}
+
+// Listen 阻塞直到服务关闭
+func (s *PushServer) Listen(addr string) error {
+	return s.newApp().Listen(addr)
+}
+
+func (s *PushServer) newApp() *iris.Application {
+	app := iris.New()
+	app.UseRouter(func(ctx iris.Context) {
+		ctx.Negotiation().JSON().Protobuf().MsgPack()
+		ctx.Negotiation().Accept.JSON()
+		ctx.Next()
+	})
+	app.Post("/api/:app/push", s.auth, s.Push)
+	return app
+}
+
+// auth token中的app必须和请求的app相同，token中的账号保存在上下文中，是调用方唯一可以使用的发送方
+func (s *PushServer) auth(c iris.Context) {
+	tk, err := token.Parse(s.secret, strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+	if err != nil || tk.App != c.Params().Get("app") {
+		c.StopWithText(iris.StatusUnauthorized, ErrPushToken.Error())
+		return
+	}
+	c.Values().Set("account", tk.Account)
+	c.Next()
+}
+
+func (s *PushServer) Push(c iris.Context) {
+	var req rpc.PushReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if err := checkPush(&req); err != nil {
+		c.StopWithText(iris.StatusBadRequest, err.Error())
+		return
+	}
+	if req.GetSender() != "" && req.GetSender() != c.Values().GetString("account") {
+		c.StopWithText(iris.StatusForbidden, ErrPushSender.Error())
+		return
+	}
+	resp, err := s.push(c.Request().Context(), c.Params().Get("app"), &req)
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	_, _ = c.Negotiate(resp)
+}
+
+func checkPush(req *rpc.PushReq) error {
+	targets := 0
+	if len(req.GetAccounts()) > 0 {
+		targets++
+	}
+	if len(req.GetTags()) > 0 {
+		targets++
+	}
+	if req.GetAll() {
+		targets++
+	}
+	if targets != 1 {
+		return ErrPushTarget
+	}
+	if len(req.GetAccounts()) > MaxPushAccounts {
+		return fmt.Errorf("too many accounts, at most %d", MaxPushAccounts)
+	}
+	if req.GetMessage() == nil {
+		return ErrPushMessage
+	}
+	if req.GetOffline() && (len(req.GetAccounts()) == 0 || req.GetSender() == "") {
+		return ErrPushOffline
+	}
+	return nil
+}
+
+// push 推送给在线的连接，需要时把不在线的账号保存为离线消息
+func (s *PushServer) push(ctx context.Context, app string, req *rpc.PushReq) (*rpc.PushResp, error) {
+	sendTime := time.Now().UnixNano()
+	body := &pkt.MessagePush{
+		Type:     req.Message.GetType(),
+		Body:     req.Message.GetBody(),
+		Extra:    req.Message.GetExtra(),
+		Sender:   req.GetSender(),
+		SendTime: sendTime,
+	}
+	if req.GetAll() {
+		s.broadcast(app, body)
+		return &rpc.PushResp{}, nil
+	}
+	locs, err := s.locations(app, req)
+	if err != nil {
+		return nil, err
+	}
+	packet := pkt.New(wire.CommandPushNotify)
+	packet.Flag = pkt.Flag_Push
+	packet.WriteBody(body)
+	// 部分网关推送失败时不影响其它网关
+	if err = kingim.Dispatch(s.dispather, packet, locs...); err != nil {
+		log.Warnf("push to app %s: %v", app, err)
+	}
+	online := make(map[string]struct{}, len(locs))
+	for _, loc := range locs {
+		online[loc.Account] = struct{}{}
+	}
+	resp := &rpc.PushResp{Online: int32(len(online))}
+	if !req.GetOffline() {
+		return resp, nil
+	}
+	for _, account := range req.GetAccounts() {
+		if _, ok := online[account]; ok {
+			continue
+		}
+		_, err = s.message.InsertUser(ctx, app, &rpc.InsertMessageReq{
+			Sender:   req.GetSender(),
+			Dest:     account,
+			SendTime: sendTime,
+			Message:  req.GetMessage(),
+		})
+		if err != nil {
+			log.Warnf("save push of %s to %s failed: %v", req.GetSender(), account, err)
+			continue
+		}
+		resp.Offline++
+	}
+	return resp, nil
+}
+
+// broadcast 每个在线的网关只推送一个包，由网关推送给本地这个app所有的连接，因此不统计在线的账号数
+func (s *PushServer) broadcast(app string, body *pkt.MessagePush) {
+	for _, gateway := range s.gateways() {
+		packet := pkt.New(wire.CommandPushNotify)
+		packet.Flag = pkt.Flag_Push
+		packet.AddStringMeta(wire.MetaDestBroadcast, app)
+		packet.WriteBody(body)
+		if err := s.dispather.Push(gateway, nil, packet); err != nil {
+			log.Warnf("broadcast to app %s on %s failed: %v", app, gateway, err)
+		}
+	}
+}
+
+// locations 指定账号时读取账号在app中的位置信息，指定标签时从标签的索引中读取会话
+func (s *PushServer) locations(app string, req *rpc.PushReq) ([]*kingim.Location, error) {
+	if len(req.GetAccounts()) > 0 {
+		locs, err := s.cache.GetLocations(req.GetAccounts()...)
+		if err != nil && err != kingim.ErrSessionNil {
+			return nil, err
+		}
+		// 其它app中的同名账号不推送，离线消息也按这个app保存
+		filtered := locs[:0]
+		for _, loc := range locs {
+			if loc.App == app {
+				filtered = append(filtered, loc)
+			}
+		}
+		return filtered, nil
+	}
+	sessions, err := s.cache.GetByTags(app, req.GetTags()...)
+	if err != nil {
+		return nil, err
+	}
+	locs := make([]*kingim.Location, len(sessions))
+	for i, session := range sessions {
+		locs[i] = &kingim.Location{
+			ChannelId: session.GetChannelId(),
+			GateId:    session.GetGateId(),
+			Device:    session.GetDevice(),
+			Account:   session.GetAccount(),
+		}
+	}
+	return locs, nil
+}
@@ -0,0 +1,174 @@
+package serv
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"kingim/services/server/service"
+	"kingim/storage"
+	"kingim/wire"
+	"kingim/wire/pkt"
+	"kingim/wire/rpc"
+	"kingim/wire/token"
+)
+
+type pushed struct {
+	gateway  string
+	channels []string
+	packet   *pkt.LogicPkt
+}
+
+type pushRecorder struct {
+	sync.Mutex
+	list []pushed
+}
+
+func (d *pushRecorder) Push(gateway string, channels []string, p *pkt.LogicPkt) error {
+	d.Lock()
+	defer d.Unlock()
+	d.list = append(d.list, pushed{gateway: gateway, channels: channels, packet: p})
+	return nil
+}
+
+// channels 推送到的所有channel，排序之后返回
+func (d *pushRecorder) channels() []string {
+	var ids []string
+	for _, p := range d.list {
+		ids = append(ids, p.channels...)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+type fakeMessage struct {
+	service.Message
+	inserted []*rpc.InsertMessageReq
+}
+
+func (m *fakeMessage) InsertUser(_ context.Context, _ string, req *rpc.InsertMessageReq) (*rpc.InsertMessageResp, error) {
+	m.inserted = append(m.inserted, req)
+	return &rpc.InsertMessageResp{MessageId: int64(len(m.inserted))}, nil
+}
+
+func TestPushServer(t *testing.T) {
+	cache := storage.NewMemoryStorage(0)
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone", Tags: []string{"vip"}})
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test1", ChannelId: "ch2", GateId: "gate2", Device: "pc"})
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test2", ChannelId: "ch3", GateId: "gate2", Device: "phone", Tags: []string{"vip"}})
+	_ = cache.Add(&pkt.Session{App: "app2", Account: "test3", ChannelId: "ch4", GateId: "gate1", Device: "phone", Tags: []string{"vip"}})
+	message := &fakeMessage{}
+	s, err := NewPushServer("secret", cache, func() []string { return []string{"gate1", "gate2"} }, message)
+	assert.Nil(t, err)
+	msg := &rpc.Message{Type: wire.MessageTypeText, Body: "hello"}
+
+	t.Run("all", func(t *testing.T) {
+		d := &pushRecorder{}
+		s.dispather = d
+		resp, err := s.push(context.Background(), "app1", &rpc.PushReq{All: true, Message: msg})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(0), resp.Online)
+		// 每个网关只推送一次，由网关推送给本地app1的连接
+		assert.Equal(t, 2, len(d.list))
+		assert.Empty(t, d.channels())
+		for _, p := range d.list {
+			assert.Equal(t, wire.CommandPushNotify, p.packet.Command)
+			assert.Equal(t, pkt.Flag_Push, p.packet.Flag)
+			app, ok := p.packet.GetMeta(wire.MetaDestBroadcast)
+			assert.True(t, ok)
+			assert.Equal(t, "app1", app)
+		}
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		d := &pushRecorder{}
+		s.dispather = d
+		resp, err := s.push(context.Background(), "app1", &rpc.PushReq{Tags: []string{"vip"}, Message: msg})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), resp.Online)
+		assert.Equal(t, []string{"ch1", "ch3"}, d.channels())
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		d := &pushRecorder{}
+		s.dispather = d
+		req := &rpc.PushReq{Accounts: []string{"test1", "test4"}, Sender: "system", Message: msg, Offline: true}
+		assert.Nil(t, checkPush(req))
+		resp, err := s.push(context.Background(), "app1", req)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(1), resp.Online)
+		assert.Equal(t, int32(1), resp.Offline)
+		assert.Equal(t, []string{"ch1", "ch2"}, d.channels())
+		assert.Equal(t, 1, len(message.inserted))
+		assert.Equal(t, "test4", message.inserted[0].Dest)
+		assert.Equal(t, "system", message.inserted[0].Sender)
+	})
+
+	t.Run("other app", func(t *testing.T) {
+		d := &pushRecorder{}
+		s.dispather = d
+		// test3只在app2中登录，app1中的同名账号按离线处理
+		req := &rpc.PushReq{Accounts: []string{"test3"}, Sender: "system", Message: msg, Offline: true}
+		resp, err := s.push(context.Background(), "app1", req)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(0), resp.Online)
+		assert.Equal(t, int32(1), resp.Offline)
+		assert.Empty(t, d.channels())
+		assert.Equal(t, "test3", message.inserted[len(message.inserted)-1].Dest)
+
+		resp, err = s.push(context.Background(), "app2", req)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(1), resp.Online)
+		assert.Equal(t, []string{"ch4"}, d.channels())
+	})
+}
+
+func Test_checkPush(t *testing.T) {
+	msg := &rpc.Message{Body: "hello"}
+	assert.Equal(t, ErrPushTarget, checkPush(&rpc.PushReq{Message: msg}))
+	assert.Equal(t, ErrPushTarget, checkPush(&rpc.PushReq{All: true, Tags: []string{"vip"}, Message: msg}))
+	assert.Equal(t, ErrPushMessage, checkPush(&rpc.PushReq{All: true}))
+	assert.Equal(t, ErrPushOffline, checkPush(&rpc.PushReq{All: true, Message: msg, Offline: true}))
+	assert.Equal(t, ErrPushOffline, checkPush(&rpc.PushReq{Accounts: []string{"test1"}, Message: msg, Offline: true}))
+	assert.Nil(t, checkPush(&rpc.PushReq{Tags: []string{"vip"}, Message: msg}))
+}
+
+func TestPushServer_Auth(t *testing.T) {
+	_, err := NewPushServer("", storage.NewMemoryStorage(0), nil, nil)
+	assert.Equal(t, ErrPushSecret, err)
+
+	s, _ := NewPushServer("secret", storage.NewMemoryStorage(0), func() []string { return nil }, &fakeMessage{})
+	s.dispather = &pushRecorder{}
+	app := s.newApp()
+	assert.Nil(t, app.Build())
+	newToken := func(secret, app, account string) string {
+		tk, _ := token.Generate(secret, &token.Token{App: app, Account: account, Exp: time.Now().Add(time.Hour).Unix()})
+		return tk
+	}
+	do := func(tk string, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/app1/push", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if tk != "" {
+			req.Header.Set("Authorization", "Bearer "+tk)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	body := `{"accounts":["test1"],"sender":"system","message":{"body":"hello"}}`
+
+	assert.Equal(t, http.StatusOK, do(newToken("secret", "app1", "system"), body))
+	assert.Equal(t, http.StatusUnauthorized, do("", body))
+	assert.Equal(t, http.StatusUnauthorized, do(newToken("other", "app1", "system"), body))
+	// token只能用于自己的app
+	assert.Equal(t, http.StatusUnauthorized, do(newToken("secret", "app2", "system"), body))
+	// 不能冒充其它账号发送
+	assert.Equal(t, http.StatusForbidden, do(newToken("secret", "app1", "admin"), body))
+	assert.Equal(t, http.StatusOK, do(newToken("secret", "app1", "admin"), `{"all":true,"message":{"body":"hello"}}`))
+}
@@ -0,0 +1,159 @@
+package serv
+
+import (
+	"sync"
+	"time"
+
+	"kingim"
+	"kingim/logger"
+	"kingim/naming"
+	"kingim/wire/pkt"
+)
+
+// DefaultPurgeDelay 网关从注册中心消失之后，等待多久再清理它的会话，避免网关短暂的健康检查失败导致误删
+const DefaultPurgeDelay = time.Second * 30
+
+// OfflineNotifier 账号的最后一个设备下线时通知订阅者
+type OfflineNotifier interface {
+	Offline(app string, account string)
+}
+
+// GatewayWatcher 监听网关的注册信息，网关下线之后批量删除它上面的所有会话和房间成员
+type GatewayWatcher struct {
+	sync.Mutex
+	naming   naming.Naming
+	cache    kingim.SessionStorage
+	rooms    kingim.RoomStorage
+	presence OfflineNotifier
+	delay    time.Duration
+	// serviceName -> 在线的网关ID
+	gateways map[string]map[string]struct{}
+}
+
+// NewGatewayWatcher rooms为nil时不清理房间成员，presence为nil时不发出离线通知
+func NewGatewayWatcher(ns naming.Naming, cache kingim.SessionStorage, rooms kingim.RoomStorage, presence OfflineNotifier, delay time.Duration) *GatewayWatcher {
+	if delay <= 0 {
+		delay = DefaultPurgeDelay
+	}
+	return &GatewayWatcher{
+		naming:   ns,
+		cache:    cache,
+		rooms:    rooms,
+		presence: presence,
+		delay:    delay,
+		gateways: make(map[string]map[string]struct{}),
+	}
+}
+
+// Watch 订阅网关服务的变化
+func (w *GatewayWatcher) Watch(serviceNames ...string) error {
+	for _, name := range serviceNames {
+		services, err := w.naming.Find(name)
+		if err != nil {
+			return err
+		}
+		w.update(name, services)
+		name := name
+		err = w.naming.Subscribe(name, func(services []kingim.ServiceRegistration) {
+			w.update(name, services)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Gateways 当前在线的网关ID
+func (w *GatewayWatcher) Gateways() []string {
+	w.Lock()
+	defer w.Unlock()
+	var ids []string
+	for _, online := range w.gateways {
+		for id := range online {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (w *GatewayWatcher) update(name string, services []kingim.ServiceRegistration) {
+	w.Lock()
+	defer w.Unlock()
+	online := make(map[string]struct{}, len(services))
+	for _, service := range services {
+		online[service.ServiceID()] = struct{}{}
+	}
+	for id := range w.gateways[name] {
+		if _, ok := online[id]; !ok {
+			log.Infof("gateway %s of %s is offline, purge its sessions after %v", id, name, w.delay)
+			time.AfterFunc(w.delay, func(id string) func() {
+				return func() {
+					w.purge(name, id)
+				}
+			}(id))
+		}
+	}
+	w.gateways[name] = online
+}
+
+// purge 如果网关在等待期间没有重新上线，就删除它上面的所有会话和房间成员
+func (w *GatewayWatcher) purge(name, id string) {
+	w.Lock()
+	_, ok := w.gateways[name][id]
+	w.Unlock()
+	if ok {
+		log.Infof("gateway %s of %s is online again", id, name)
+		return
+	}
+	var sessions []*pkt.Session
+	if w.presence != nil {
+		var err error
+		if sessions, err = w.cache.GetByGate(id); err != nil {
+			logger.WithField("func", "purge").Warnf("get sessions of gateway %s failed: %v", id, err)
+		}
+	}
+	count, err := w.cache.DeleteByGate(id)
+	if err != nil {
+		logger.WithField("func", "purge").Errorf("purge sessions of gateway %s failed: %v", id, err)
+	} else {
+		log.Infof("purged %d sessions of gateway %s", count, id)
+		w.offline(sessions)
+	}
+	if w.rooms == nil {
+		return
+	}
+	count, err = w.rooms.DeleteByGate(id)
+	if err != nil {
+		logger.WithField("func", "purge").Errorf("purge rooms of gateway %s failed: %v", id, err)
+		return
+	}
+	log.Infof("purged members of gateway %s from %d rooms", id, count)
+}
+
+// offline 被清理的会话所属的账号在其它网关上也没有在线的设备时，发出离线通知
+func (w *GatewayWatcher) offline(sessions []*pkt.Session) {
+	seen := make(map[string]struct{}, len(sessions))
+	for _, session := range sessions {
+		key := session.GetApp() + ":" + session.GetAccount()
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		locs, err := w.cache.GetLocations(session.GetAccount())
+		if err != nil && err != kingim.ErrSessionNil {
+			logger.WithField("func", "purge").Warnf("get locations of %s failed: %v", session.GetAccount(), err)
+			continue
+		}
+		online := false
+		for _, loc := range locs {
+			if loc.App == session.GetApp() {
+				online = true
+				break
+			}
+		}
+		if !online {
+			w.presence.Offline(session.GetApp(), session.GetAccount())
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package serv
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"kingim"
+	"kingim/naming"
+	"kingim/storage"
+	"kingim/wire/pkt"
+)
+
+type fakeNaming struct {
+	naming.Naming
+	services  []kingim.ServiceRegistration
+	callbacks map[string]func([]kingim.ServiceRegistration)
+}
+
+func (n *fakeNaming) Find(string, ...string) ([]kingim.ServiceRegistration, error) {
+	return n.services, nil
+}
+
+func (n *fakeNaming) Subscribe(name string, callback func([]kingim.ServiceRegistration)) error {
+	n.callbacks[name] = callback
+	return nil
+}
+
+func TestGatewayWatcher(t *testing.T) {
+	gate1 := naming.NewEntry("gate1", "wgateway", "ws", "127.0.0.1", 8000)
+	gate2 := naming.NewEntry("gate2", "wgateway", "ws", "127.0.0.1", 8001)
+	ns := &fakeNaming{
+		services:  []kingim.ServiceRegistration{gate1, gate2},
+		callbacks: make(map[string]func([]kingim.ServiceRegistration)),
+	}
+	cache := storage.NewMemoryStorage(0)
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test1", ChannelId: "ch1", GateId: "gate1"})
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test2", ChannelId: "ch2", GateId: "gate2"})
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test3", ChannelId: "ch3", GateId: "gate1", Device: "phone"})
+	_ = cache.Add(&pkt.Session{App: "app1", Account: "test3", ChannelId: "ch4", GateId: "gate2", Device: "pc"})
+	rooms := storage.NewMemoryRoomStorage()
+	_ = rooms.Join("room1", "gate1", "ch1")
+	_ = rooms.Join("room1", "gate2", "ch2")
+
+	presence := &offlineRecorder{}
+	w := NewGatewayWatcher(ns, cache, rooms, presence, time.Millisecond*20)
+	assert.Nil(t, w.Watch("wgateway"))
+
+	// gate1 下线，gate2 短暂下线之后在等待期内重新上线
+	ns.callbacks["wgateway"](nil)
+	ns.callbacks["wgateway"]([]kingim.ServiceRegistration{gate2})
+	time.Sleep(time.Millisecond * 100)
+
+	_, err := cache.Get("ch1")
+	assert.Equal(t, kingim.ErrSessionNil, err)
+	_, err = cache.Get("ch2")
+	assert.Nil(t, err)
+	gates, err := rooms.Gateways("room1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gate2"}, gates)
+	// test3 在gate2上还有在线的设备
+	assert.Equal(t, []string{"app1:test1"}, presence.list())
+}
+
+type offlineRecorder struct {
+	sync.Mutex
+	accounts []string
+}
+
+func (r *offlineRecorder) Offline(app string, account string) {
+	r.Lock()
+	defer r.Unlock()
+	r.accounts = append(r.accounts, app+":"+account)
+}
+
+func (r *offlineRecorder) list() []string {
+	r.Lock()
+	defer r.Unlock()
+	return r.accounts
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"github.com/spf13/cobra"
 	"kingim/container"
 	"kingim/naming"
@@ -12,6 +13,7 @@ import (
 	"kingim/logger"
 	"kingim/services/server/conf"
 	"kingim/services/server/handler"
+	"kingim/services/server/service"
 	"kingim/storage"
 	"kingim/wire"
 )
@@ -45,19 +47,116 @@ func RunServerStart(ctx context.Context, opts*ServerStartOptions, version string
 	_ = logger.Init(logger.Settings{
 		Level: "trace",
 	})
-	// 初始化路由
-	r := kingim.NewRouter()
-	// login
-	loginHandler := handler.NewLoginHandler()
-	r.Handle(wire.CommandLoginSignIn, loginHandler.DoSysLogin)  // 注入方法
-	r.Handle(wire.CommandLoginSignOut, loginHandler.DoSysLogout)
-
 	// 初始化Redis
 	rdb, err := conf.InitRedis(config.RedisAddrs, "")
 	if err != nil {
 		return err
 	}
-	cache := storage.NewRedisStorage(rdb)
+	cache := storage.NewRedisStorage(rdb, config.SessionTTL)
+
+	// 初始化路由
+	r := kingim.NewRouter()
+	// chat服务中推送接口保存离线消息时使用
+	var messageService service.Message
+	// chat服务清理宕机网关的会话之后发出离线通知
+	var presence *handler.PresenceHandler
+	switch opts.serviceName {
+	case wire.SNLogin:
+		// presence
+		presenceHandler := handler.NewPresenceHandler(&serv.ServerDispather{}, cache, storage.NewRedisPresenceStorage(rdb))
+		defer presenceHandler.Close()
+		// 和单聊使用相同的关系检查
+		err = presenceHandler.SetTalkOptions(talkOptions(config, service.NewContactService(config.RoyalURL)))
+		if err != nil {
+			return err
+		}
+		r.Handle(wire.CommandPresenceSubscribe, presenceHandler.DoSubscribe)
+		r.Handle(wire.CommandPresenceQuery, presenceHandler.DoQuery)
+		r.Handle(wire.CommandPresenceStatus, presenceHandler.DoStatus)
+		// login
+		loginHandler, err := handler.NewLoginHandler(handler.LoginOptions{
+			Policy: handler.KickPolicy(config.KickPolicy),
+			MaxDevices: config.MaxDevices,
+			Presence: presenceHandler,
+			Rooms: storage.NewRedisRoomStorage(rdb),
+		})
+		if err != nil {
+			return err
+		}
+		r.Handle(wire.CommandLoginSignIn, loginHandler.DoSysLogin)  // 注入方法
+		r.Handle(wire.CommandLoginSignOut, loginHandler.DoSysLogout)
+		r.Handle(wire.CommandLoginRefresh, loginHandler.DoSysRefresh)
+	case wire.SNChat:
+		messageService = service.NewMessageService(config.RoyalURL)
+		groupService := service.NewGroupService(config.RoyalURL)
+		contactService := service.NewContactService(config.RoyalURL)
+		presence = handler.NewPresenceHandler(&serv.ServerDispather{}, cache, storage.NewRedisPresenceStorage(rdb))
+		defer presence.Close()
+		if err = presence.SetTalkOptions(talkOptions(config, contactService)); err != nil {
+			return err
+		}
+		// talk
+		acks := handler.NewAckTracker(&serv.ServerDispather{}, cache, storage.NewRedisAckStorage(rdb), handler.AckOptions{
+			Timeout: config.AckTimeout,
+			MaxRetries: config.AckMaxRetries,
+		})
+		defer acks.Close()
+		chatHandler := handler.NewChatHandler(messageService, groupService, acks)
+		err = chatHandler.SetTalkOptions(talkOptions(config, contactService))
+		if err != nil {
+			return err
+		}
+		r.Handle(wire.CommandChatUserTalk, chatHandler.DoUserTalk)
+		r.Handle(wire.CommandChatGroupTalk, chatHandler.DoGroupTalk)
+		r.Handle(wire.CommandChatTalkAck, chatHandler.DoTalkAck)
+		r.Handle(wire.CommandChatMessageRecall, chatHandler.DoRecall)
+		r.Handle(wire.CommandChatMessageEdit, chatHandler.DoEdit)
+		r.Handle(wire.CommandChatRead, chatHandler.DoRead)
+		r.Handle(wire.CommandChatSignal, kingim.RateLimit(kingim.NewRateLimiter(config.SignalRate, config.SignalBurst)), chatHandler.DoSignal)
+		// group
+		groupHandler := handler.NewGroupHandler(groupService)
+		r.Handle(wire.CommandGroupCreate, groupHandler.DoCreate)
+		r.Handle(wire.CommandGroupJoin, groupHandler.DoJoin)
+		r.Handle(wire.CommandGroupQuit, groupHandler.DoQuit)
+		r.Handle(wire.CommandGroupMembers, groupHandler.DoMembers)
+		r.Handle(wire.CommandGroupDetail, groupHandler.DoDetail)
+		r.Handle(wire.CommandGroupKick, groupHandler.DoKick)
+		r.Handle(wire.CommandGroupRole, groupHandler.DoRole)
+		r.Handle(wire.CommandGroupTransfer, groupHandler.DoTransfer)
+		r.Handle(wire.CommandGroupJoinMode, groupHandler.DoJoinMode)
+		r.Handle(wire.CommandGroupDissolve, groupHandler.DoDissolve)
+		r.Handle(wire.CommandGroupMute, groupHandler.DoMute)
+		r.Handle(wire.CommandGroupMemberMute, groupHandler.DoMemberMute)
+		r.Handle(wire.CommandGroupAnnouncement, groupHandler.DoAnnouncement)
+		r.Handle(wire.CommandGroupJoinRequests, groupHandler.DoJoinRequests)
+		r.Handle(wire.CommandGroupJoinHandle, groupHandler.DoJoinHandle)
+		// offline
+		offlineHandler := handler.NewOfflineHandler(messageService)
+		r.Handle(wire.CommandOfflineIndex, offlineHandler.DoSyncIndex)
+		r.Handle(wire.CommandOfflineContent, offlineHandler.DoSyncContent)
+		// conversation
+		conversationHandler := handler.NewConversationHandler(messageService)
+		r.Handle(wire.CommandConversationList, conversationHandler.DoList)
+		r.Handle(wire.CommandConversationUpdate, conversationHandler.DoUpdate)
+		r.Handle(wire.CommandChatHistory, conversationHandler.DoHistory)
+		r.Handle(wire.CommandChatMessageSearch, conversationHandler.DoSearch)
+		// contact
+		contactHandler := handler.NewContactHandler(contactService)
+		r.Handle(wire.CommandContactRequest, contactHandler.DoRequest)
+		r.Handle(wire.CommandContactHandle, contactHandler.DoHandle)
+		r.Handle(wire.CommandContactRequests, contactHandler.DoRequests)
+		r.Handle(wire.CommandContactList, contactHandler.DoList)
+		r.Handle(wire.CommandContactRemove, contactHandler.DoRemove)
+		r.Handle(wire.CommandContactBlock, contactHandler.DoBlock)
+		r.Handle(wire.CommandContactRemark, contactHandler.DoRemark)
+		// room
+		roomHandler := handler.NewRoomHandler(storage.NewRedisRoomStorage(rdb))
+		r.Handle(wire.CommandRoomJoin, roomHandler.DoJoin)
+		r.Handle(wire.CommandRoomLeave, roomHandler.DoLeave)
+		r.Handle(wire.CommandRoomTalk, roomHandler.DoTalk)
+	default:
+		return fmt.Errorf("unknown serviceName %s, option is %s or %s", opts.serviceName, wire.SNLogin, wire.SNChat)
+	}
 
 	servhandler := serv.NewServHandler(r, cache)
 	service := &naming.DefaultService{
@@ -70,6 +169,16 @@ func RunServerStart(ctx context.Context, opts*ServerStartOptions, version string
 	}
 	srv := tcp.NewServer(config.Listen, service)
 
+	strategy, err := kingim.NewDispatchStrategy(kingim.DispatchOptions{
+		Mode: kingim.DispatchMode(config.DispatchMode),
+		Workers: config.DispatchWorkers,
+		QueueSize: config.DispatchQueueSize,
+		OrderKey: serv.OrderKey,
+	})
+	if err != nil {
+		return err
+	}
+	srv.SetDispatchStrategy(strategy)
 	srv.SetReadWait(kingim.DefaultReadWait)
 	srv.SetAcceptor(servhandler)
 	srv.SetMessageListener(servhandler)
@@ -83,5 +192,37 @@ func RunServerStart(ctx context.Context, opts*ServerStartOptions, version string
 		return err
 	}
 	container.SetServiceNaming(ns)
+	if opts.serviceName == wire.SNChat {
+		// 网关宕机时不会发送登出请求，由聊天服务清理它上面的会话和房间成员，并发出离线通知
+		watcher := serv.NewGatewayWatcher(ns, cache, storage.NewRedisRoomStorage(rdb), presence, config.GatewayPurgeDelay)
+		if err = watcher.Watch(wire.SNWGateway, wire.SNTGateway); err != nil {
+			return err
+		}
+		if config.PushListen != "" {
+			pushServer, err := serv.NewPushServer(config.PushSecret, cache, watcher.Gateways, messageService)
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := pushServer.Listen(config.PushListen); err != nil {
+					logger.Error(err)
+				}
+			}()
+		}
+	}
+	_ = container.EnableMonitor(fmt.Sprintf(":%d", config.MonitorPort))
 	return container.Start()
 }
+
+// talkOptions 单聊和状态订阅的关系检查配置
+func talkOptions(config *conf.Config, contact service.Contact) handler.TalkOptions {
+	apps := make(map[string]handler.TalkPolicy, len(config.AppTalkPolicies))
+	for app, policy := range config.AppTalkPolicies {
+		apps[app] = handler.TalkPolicy(policy)
+	}
+	return handler.TalkOptions{
+		Contact: contact,
+		Policy:  handler.TalkPolicy(config.TalkPolicy),
+		Apps:    apps,
+	}
+}
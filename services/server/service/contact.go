@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/protobuf/proto"
+	"kingim/wire/rpc"
+)
+
+type Contact interface {
+	Request(ctx context.Context, app string, req *rpc.FriendRequestReq) (*rpc.FriendRequestResp, error)
+	Handle(ctx context.Context, app string, req *rpc.HandleFriendRequestReq) (*rpc.HandleFriendRequestResp, error)
+	Requests(ctx context.Context, app string, account string) (*rpc.FriendRequestsResp, error)
+	List(ctx context.Context, app string, account string) (*rpc.ContactsResp, error)
+	Remove(ctx context.Context, app string, req *rpc.ContactReq) error
+	Block(ctx context.Context, app string, req *rpc.BlockContactReq) error
+	Remark(ctx context.Context, app string, req *rpc.RemarkContactReq) error
+	// Relation account给peer发消息时双方的关系
+	Relation(ctx context.Context, app string, account, peer string) (*rpc.RelationResp, error)
+}
+
+type ContactHttp struct {
+	url string
+	cli *resty.Client
+	srv *resty.SRVRecord
+}
+
+func NewContactService(url string) Contact {
+	cli := resty.New().SetRetryCount(3).SetTimeout(time.Second * 5)
+	cli.SetHeader("Content-Type", "application/x-protobuf")
+	cli.SetHeader("Accept", "application/x-protobuf")
+	cli.SetScheme("http")
+	return &ContactHttp{
+		url: url,
+		cli: cli,
+	}
+}
+
+func NewContactServiceWithSRV(scheme string, srv *resty.SRVRecord) Contact {
+	cli := resty.New().SetRetryCount(3).SetTimeout(time.Second * 5)
+	cli.SetHeader("Content-Type", "application/x-protobuf")
+	cli.SetHeader("Accept", "application/x-protobuf")
+	cli.SetScheme("http")
+	return &ContactHttp{
+		url: "",
+		cli: cli,
+		srv: srv,
+	}
+}
+
+func (c *ContactHttp) Request(ctx context.Context, app string, req *rpc.FriendRequestReq) (*rpc.FriendRequestResp, error) {
+	path := fmt.Sprintf("%s/api/%s/contact/request", c.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := c.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	switch response.StatusCode() {
+	case 200:
+	case 400:
+		return nil, ErrInvalid
+	case 403:
+		return nil, ErrForbidden
+	case 409:
+		return nil, ErrConflict
+	default:
+		return nil, fmt.Errorf("ContactHttp.Request response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.FriendRequestResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (c *ContactHttp) Handle(ctx context.Context, app string, req *rpc.HandleFriendRequestReq) (*rpc.HandleFriendRequestResp, error) {
+	path := fmt.Sprintf("%s/api/%s/contact/request/handle", c.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := c.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	switch response.StatusCode() {
+	case 200:
+	case 404:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("ContactHttp.Handle response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.HandleFriendRequestResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (c *ContactHttp) Requests(ctx context.Context, app string, account string) (*rpc.FriendRequestsResp, error) {
+	path := fmt.Sprintf("%s/api/%s/contact/requests/%s", c.url, app, account)
+	response, err := c.Req(ctx).Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != 200 {
+		return nil, fmt.Errorf("ContactHttp.Requests response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.FriendRequestsResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (c *ContactHttp) List(ctx context.Context, app string, account string) (*rpc.ContactsResp, error) {
+	path := fmt.Sprintf("%s/api/%s/contact/list/%s", c.url, app, account)
+	response, err := c.Req(ctx).Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != 200 {
+		return nil, fmt.Errorf("ContactHttp.List response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.ContactsResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (c *ContactHttp) Remove(ctx context.Context, app string, req *rpc.ContactReq) error {
+	path := fmt.Sprintf("%s/api/%s/contact/friend", c.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := c.Req(ctx).SetBody(body).Delete(path)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() != 200 {
+		return fmt.Errorf("ContactHttp.Remove response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	return nil
+}
+
+func (c *ContactHttp) Block(ctx context.Context, app string, req *rpc.BlockContactReq) error {
+	path := fmt.Sprintf("%s/api/%s/contact/block", c.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := c.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() != 200 {
+		return fmt.Errorf("ContactHttp.Block response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	return nil
+}
+
+func (c *ContactHttp) Remark(ctx context.Context, app string, req *rpc.RemarkContactReq) error {
+	path := fmt.Sprintf("%s/api/%s/contact/remark", c.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := c.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() != 200 {
+		return fmt.Errorf("ContactHttp.Remark response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	return nil
+}
+
+func (c *ContactHttp) Relation(ctx context.Context, app string, account, peer string) (*rpc.RelationResp, error) {
+	path := fmt.Sprintf("%s/api/%s/contact/relation/%s/%s", c.url, app, account, peer)
+	response, err := c.Req(ctx).Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != 200 {
+		return nil, fmt.Errorf("ContactHttp.Relation response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.RelationResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (c *ContactHttp) Req(ctx context.Context) *resty.Request {
+	if c.srv == nil {
+		return c.cli.R().SetContext(ctx)
+	}
+	return c.cli.R().SetContext(ctx).SetSRV(c.srv)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"github.com/go-resty/resty/v2"
 	"github.com/golang/protobuf/proto"
@@ -10,11 +11,21 @@ import (
 )
 
 type Group interface {
-	Create(app string, req *rpc.CreateGroupReq) (*rpc.CreateGroupResp, error)
-	Members(app string, req *rpc.GroupMembersReq) (*rpc.GroupMembersResp, error)
-	Join(app string, req *rpc.JoinGroupReq) error
-	Quit(app string, req *rpc.QuitGroupReq) error
-	Detail(app string, req *rpc.GetGroupReq) (*rpc.GetGroupResp, error)
+	Create(ctx context.Context, app string, req *rpc.CreateGroupReq) (*rpc.CreateGroupResp, error)
+	Members(ctx context.Context, app string, req *rpc.GroupMembersReq) (*rpc.GroupMembersResp, error)
+	Join(ctx context.Context, app string, req *rpc.JoinGroupReq) (*rpc.JoinGroupResp, error)
+	Quit(ctx context.Context, app string, req *rpc.QuitGroupReq) error
+	Detail(ctx context.Context, app string, req *rpc.GetGroupReq) (*rpc.GetGroupResp, error)
+	Kick(ctx context.Context, app string, req *rpc.KickGroupMemberReq) error
+	SetRole(ctx context.Context, app string, req *rpc.SetGroupRoleReq) error
+	Transfer(ctx context.Context, app string, req *rpc.TransferGroupReq) error
+	SetJoinMode(ctx context.Context, app string, req *rpc.SetGroupJoinModeReq) error
+	Dissolve(ctx context.Context, app string, req *rpc.DissolveGroupReq) error
+	Mute(ctx context.Context, app string, req *rpc.MuteGroupReq) error
+	MuteMember(ctx context.Context, app string, req *rpc.MuteMemberReq) (*rpc.MuteMemberResp, error)
+	SetAnnouncement(ctx context.Context, app string, req *rpc.SetAnnouncementReq) (*rpc.SetAnnouncementResp, error)
+	JoinRequests(ctx context.Context, app string, req *rpc.GroupJoinRequestsReq) (*rpc.GroupJoinRequestsResp, error)
+	HandleJoin(ctx context.Context, app string, req *rpc.HandleGroupJoinReq) (*rpc.HandleGroupJoinResp, error)
 }
 
 type GroupHttp struct {
@@ -47,10 +58,10 @@ func NewGroupServiceWithSRV(scheme string, srv *resty.SRVRecord) Group {
 	}
 }
 
-func (g *GroupHttp) Create(app string, req *rpc.CreateGroupReq) (*rpc.CreateGroupResp, error) {
+func (g *GroupHttp) Create(ctx context.Context, app string, req *rpc.CreateGroupReq) (*rpc.CreateGroupResp, error) {
 	path := fmt.Sprintf("%s/api/%s/group",g.url, app)
 	body, _  := proto.Marshal(req)
-	response, err := g.Req().SetBody(body).Post(path)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
 	if err != nil {
 		return nil, err
 	}
@@ -62,9 +73,9 @@ func (g *GroupHttp) Create(app string, req *rpc.CreateGroupReq) (*rpc.CreateGrou
 	return &resp, nil
 }
 
-func (g*GroupHttp) Members(app string, req *rpc.GroupMembersReq) (*rpc.GroupMembersResp, error) {
+func (g*GroupHttp) Members(ctx context.Context, app string, req *rpc.GroupMembersReq) (*rpc.GroupMembersResp, error) {
 	path := fmt.Sprintf("%s/api/%s/group/members/%s", g.url, app, req.GroupId)
-	response ,err := g.Req().Get(path)
+	response ,err := g.Req(ctx).Get(path)
 	if err != nil {
 		return nil,err
 	}
@@ -76,35 +87,34 @@ func (g*GroupHttp) Members(app string, req *rpc.GroupMembersReq) (*rpc.GroupMemb
 	return &resp, nil
 }
 
-func (g*GroupHttp) Join(app string, req *rpc.JoinGroupReq) error {
+func (g*GroupHttp) Join(ctx context.Context, app string, req *rpc.JoinGroupReq) (*rpc.JoinGroupResp, error) {
 	path := fmt.Sprintf("%s/api/%s/group/member", g.url, app)
 	body,_ := proto.Marshal(req)
-	response, err := g.Req().SetBody(body).Get(path)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if response.StatusCode() != 200 {
-		return fmt.Errorf("GroupHttp.Join response.StatusCode() = %d, want 200", response.StatusCode())
+	if err = groupError("Join", response); err != nil {
+		return nil, err
 	}
-	return nil
+	var resp rpc.JoinGroupResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
 }
 
-func (g*GroupHttp) Quit(app string, req *rpc.QuitGroupReq) error {
+func (g*GroupHttp) Quit(ctx context.Context, app string, req *rpc.QuitGroupReq) error {
 	path := fmt.Sprintf("%s/api/%s/group/member", g.url, app)
 	body ,_ := proto.Marshal(req)
-	response, err := g.Req().SetBody(body).Get(path)
+	response, err := g.Req(ctx).SetBody(body).Delete(path)
 	if err != nil {
 		return err
 	}
-	if response.StatusCode() != 200 {
-		return fmt.Errorf("GroupHttp.Quit response.StatusCode() = %d, want 200", response.StatusCode())
-	}
-	return nil
+	return groupError("Quit", response)
 }
 
-func (g*GroupHttp) Detail(app string, req *rpc.GetGroupReq) (*rpc.GetGroupResp, error) {
+func (g*GroupHttp) Detail(ctx context.Context, app string, req *rpc.GetGroupReq) (*rpc.GetGroupResp, error) {
 	path := fmt.Sprintf("%s/api/%s/group/%s", g.url, app, req.GroupId)
-	response, err := g.Req().Get(path)
+	response, err := g.Req(ctx).Get(path)
 	if err != nil {
 		return nil,err
 	}
@@ -117,9 +127,145 @@ func (g*GroupHttp) Detail(app string, req *rpc.GetGroupReq) (*rpc.GetGroupResp,
 	return &resp, nil
 }
 
-func (g*GroupHttp) Req() *resty.Request {
+func (g *GroupHttp) Kick(ctx context.Context, app string, req *rpc.KickGroupMemberReq) error {
+	path := fmt.Sprintf("%s/api/%s/group/member/kick", g.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return err
+	}
+	return groupError("Kick", response)
+}
+
+func (g *GroupHttp) SetRole(ctx context.Context, app string, req *rpc.SetGroupRoleReq) error {
+	path := fmt.Sprintf("%s/api/%s/group/member/role", g.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return err
+	}
+	return groupError("SetRole", response)
+}
+
+func (g *GroupHttp) Transfer(ctx context.Context, app string, req *rpc.TransferGroupReq) error {
+	path := fmt.Sprintf("%s/api/%s/group/transfer", g.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return err
+	}
+	return groupError("Transfer", response)
+}
+
+func (g *GroupHttp) SetJoinMode(ctx context.Context, app string, req *rpc.SetGroupJoinModeReq) error {
+	path := fmt.Sprintf("%s/api/%s/group/joinmode", g.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return err
+	}
+	return groupError("SetJoinMode", response)
+}
+
+func (g *GroupHttp) Dissolve(ctx context.Context, app string, req *rpc.DissolveGroupReq) error {
+	path := fmt.Sprintf("%s/api/%s/group", g.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := g.Req(ctx).SetBody(body).Delete(path)
+	if err != nil {
+		return err
+	}
+	return groupError("Dissolve", response)
+}
+
+func (g *GroupHttp) Mute(ctx context.Context, app string, req *rpc.MuteGroupReq) error {
+	path := fmt.Sprintf("%s/api/%s/group/mute", g.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return err
+	}
+	return groupError("Mute", response)
+}
+
+func (g *GroupHttp) MuteMember(ctx context.Context, app string, req *rpc.MuteMemberReq) (*rpc.MuteMemberResp, error) {
+	path := fmt.Sprintf("%s/api/%s/group/member/mute", g.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = groupError("MuteMember", response); err != nil {
+		return nil, err
+	}
+	var resp rpc.MuteMemberResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (g *GroupHttp) SetAnnouncement(ctx context.Context, app string, req *rpc.SetAnnouncementReq) (*rpc.SetAnnouncementResp, error) {
+	path := fmt.Sprintf("%s/api/%s/group/announcement", g.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = groupError("SetAnnouncement", response); err != nil {
+		return nil, err
+	}
+	var resp rpc.SetAnnouncementResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (g *GroupHttp) JoinRequests(ctx context.Context, app string, req *rpc.GroupJoinRequestsReq) (*rpc.GroupJoinRequestsResp, error) {
+	path := fmt.Sprintf("%s/api/%s/group/join/requests", g.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = groupError("JoinRequests", response); err != nil {
+		return nil, err
+	}
+	var resp rpc.GroupJoinRequestsResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (g *GroupHttp) HandleJoin(ctx context.Context, app string, req *rpc.HandleGroupJoinReq) (*rpc.HandleGroupJoinResp, error) {
+	path := fmt.Sprintf("%s/api/%s/group/join/handle", g.url, app)
+	body, _ := proto.Marshal(req)
+	response, err := g.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = groupError("HandleJoin", response); err != nil {
+		return nil, err
+	}
+	var resp rpc.HandleGroupJoinResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+// groupError 把royal返回的状态码转换为对应的错误
+func groupError(method string, response *resty.Response) error {
+	switch response.StatusCode() {
+	case 200:
+		return nil
+	case 400:
+		return ErrInvalid
+	case 403:
+		return ErrForbidden
+	case 404:
+		return ErrNotFound
+	default:
+		return fmt.Errorf("GroupHttp.%s response.StatusCode() = %d, want 200", method, response.StatusCode())
+	}
+}
+
+func (g *GroupHttp) Req(ctx context.Context) *resty.Request {
 	if g.srv == nil {
-		return g.cli.R()
+		return g.cli.R().SetContext(ctx)
 	}
-	return g.cli.R().SetSRV(g.srv)
+	return g.cli.R().SetContext(ctx).SetSRV(g.srv)
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/go-resty/resty/v2"
 	"github.com/golang/protobuf/proto"
@@ -9,12 +11,32 @@ import (
 	"time"
 )
 
+var (
+	// ErrInvalid royal返回400，请求的参数不合法
+	ErrInvalid = errors.New("invalid request")
+	// ErrNotFound royal返回404，请求的资源不存在
+	ErrNotFound = errors.New("not found")
+	// ErrForbidden royal返回403，没有权限操作
+	ErrForbidden = errors.New("forbidden")
+	// ErrExpired royal返回410，超过了允许操作的时间
+	ErrExpired = errors.New("expired")
+	// ErrConflict royal返回409，和已有的状态冲突
+	ErrConflict = errors.New("conflict")
+)
+
 type Message interface {
-	InsertUser(app string, req *rpc.InsertMessageReq) (*rpc.InsertMessageResp, error)
-	InsertGroup(app string, req *rpc.InsertMessageReq) (*rpc.InsertMessageResp, error)
-	SetAck(app string, req *rpc.AckMessageReq) error
-	GetMessageIndex(app string, req *rpc.GetOfflineMessageIndexReq) (*rpc.GetOfflineMessageIndexResp, error)
-	GetMessageContent(app string, req *rpc.GetOfflineMessageContentReq) (*rpc.GetOfflineMessageContentResp, error)
+	InsertUser(ctx context.Context, app string, req *rpc.InsertMessageReq) (*rpc.InsertMessageResp, error)
+	InsertGroup(ctx context.Context, app string, req *rpc.InsertMessageReq) (*rpc.InsertMessageResp, error)
+	SetAck(ctx context.Context, app string, req *rpc.AckMessageReq) error
+	Recall(ctx context.Context, app string, req *rpc.RecallMessageReq) (*rpc.RecallMessageResp, error)
+	Edit(ctx context.Context, app string, req *rpc.EditMessageReq) (*rpc.EditMessageResp, error)
+	SetRead(ctx context.Context, app string, req *rpc.ReadMessageReq) (*rpc.ReadMessageResp, error)
+	ListConversations(ctx context.Context, app string, req *rpc.ConversationListReq) (*rpc.ConversationListResp, error)
+	UpdateConversation(ctx context.Context, app string, req *rpc.ConversationUpdateReq) error
+	GetHistory(ctx context.Context, app string, req *rpc.GetHistoryReq) (*rpc.GetHistoryResp, error)
+	Search(ctx context.Context, app string, req *rpc.SearchMessageReq) (*rpc.SearchMessageResp, error)
+	GetMessageIndex(ctx context.Context, app string, req *rpc.GetOfflineMessageIndexReq) (*rpc.GetOfflineMessageIndexResp, error)
+	GetMessageContent(ctx context.Context, app string, req *rpc.GetOfflineMessageContentReq) (*rpc.GetOfflineMessageContentResp, error)
 }
 
 type MessageHttp struct {
@@ -45,11 +67,11 @@ func NewMessageServiceWithSRV(scheme string, srv *resty.SRVRecord) Message {
 	}
 }
 
-func (m*MessageHttp) InsertUser(app string, req *rpc.InsertMessageReq) (*rpc.InsertMessageResp, error) {
+func (m*MessageHttp) InsertUser(ctx context.Context, app string, req *rpc.InsertMessageReq) (*rpc.InsertMessageResp, error) {
 	path := fmt.Sprintf("%s/api/%s/message/user", m.url, app)
 	t1 := time.Now()
 	body,_ := proto.Marshal(req)
-	response ,err := m.Req().SetBody(body).Get(path)
+	response ,err := m.Req(ctx).SetBody(body).Post(path)
 	if err != nil {
 		return nil, err
 	}
@@ -61,11 +83,11 @@ func (m*MessageHttp) InsertUser(app string, req *rpc.InsertMessageReq) (*rpc.Ins
 	logger.Debugf("MessageHttp.InsertUser cost %v resp: %v", time.Since(t1), &resp)
 	return &resp, nil
 }
-func (m*MessageHttp) InsertGroup(app string, req *rpc.InsertMessageReq) (*rpc.InsertMessageResp, error) {
+func (m*MessageHttp) InsertGroup(ctx context.Context, app string, req *rpc.InsertMessageReq) (*rpc.InsertMessageResp, error) {
 	path := fmt.Sprintf("%s/api/%s/message/group", m.url, app)
 	t1 := time.Now()
 	body ,_ := proto.Marshal(req)
-	response, err := m.Req().SetBody(body).Get(path)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
 	if err != nil {
 		return nil, err
 	}
@@ -78,10 +100,10 @@ func (m*MessageHttp) InsertGroup(app string, req *rpc.InsertMessageReq) (*rpc.In
 	return &resp,nil
 }
 
-func (m*MessageHttp) SetAck(app string, req *rpc.AckMessageReq) error {
+func (m*MessageHttp) SetAck(ctx context.Context, app string, req *rpc.AckMessageReq) error {
 	path := fmt.Sprintf("%s/api/%s/message/ack", m.url, app)
 	body,_ := proto.Marshal(req)
-	response, err := m.Req().SetBody(body).Get(path)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
 	if err != nil {
 		return err
 	}
@@ -91,10 +113,137 @@ func (m*MessageHttp) SetAck(app string, req *rpc.AckMessageReq) error {
 	return nil
 }
 
-func (m * MessageHttp) GetMessageIndex(app string, req *rpc.GetOfflineMessageIndexReq) (*rpc.GetOfflineMessageIndexResp, error) {
+func (m*MessageHttp) Recall(ctx context.Context, app string, req *rpc.RecallMessageReq) (*rpc.RecallMessageResp, error) {
+	path := fmt.Sprintf("%s/api/%s/message/recall", m.url, app)
+	body,_ := proto.Marshal(req)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	switch response.StatusCode() {
+	case 200:
+	case 403:
+		return nil, ErrForbidden
+	case 410:
+		return nil, ErrExpired
+	default:
+		return nil, fmt.Errorf("MessageHttp.Recall response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.RecallMessageResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (m*MessageHttp) Edit(ctx context.Context, app string, req *rpc.EditMessageReq) (*rpc.EditMessageResp, error) {
+	path := fmt.Sprintf("%s/api/%s/message/edit", m.url, app)
+	body,_ := proto.Marshal(req)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	switch response.StatusCode() {
+	case 200:
+	case 400:
+		return nil, ErrInvalid
+	case 403:
+		return nil, ErrForbidden
+	default:
+		return nil, fmt.Errorf("MessageHttp.Edit response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.EditMessageResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (m*MessageHttp) SetRead(ctx context.Context, app string, req *rpc.ReadMessageReq) (*rpc.ReadMessageResp, error) {
+	path := fmt.Sprintf("%s/api/%s/message/read", m.url, app)
+	body,_ := proto.Marshal(req)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() == 404 {
+		return nil, ErrNotFound
+	}
+	if response.StatusCode() != 200 {
+		return nil, fmt.Errorf("MessageHttp.SetRead response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.ReadMessageResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (m*MessageHttp) ListConversations(ctx context.Context, app string, req *rpc.ConversationListReq) (*rpc.ConversationListResp, error) {
+	path := fmt.Sprintf("%s/api/%s/conversation/list", m.url, app)
+	body,_ := proto.Marshal(req)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != 200 {
+		return nil, fmt.Errorf("MessageHttp.ListConversations response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.ConversationListResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (m*MessageHttp) UpdateConversation(ctx context.Context, app string, req *rpc.ConversationUpdateReq) error {
+	path := fmt.Sprintf("%s/api/%s/conversation/update", m.url, app)
+	body,_ := proto.Marshal(req)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() == 404 {
+		return ErrNotFound
+	}
+	if response.StatusCode() != 200 {
+		return fmt.Errorf("MessageHttp.UpdateConversation response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	return nil
+}
+
+func (m*MessageHttp) GetHistory(ctx context.Context, app string, req *rpc.GetHistoryReq) (*rpc.GetHistoryResp, error) {
+	path := fmt.Sprintf("%s/api/%s/history", m.url, app)
+	body,_ := proto.Marshal(req)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() == 404 {
+		return nil, ErrNotFound
+	}
+	if response.StatusCode() != 200 {
+		return nil, fmt.Errorf("MessageHttp.GetHistory response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.GetHistoryResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (m*MessageHttp) Search(ctx context.Context, app string, req *rpc.SearchMessageReq) (*rpc.SearchMessageResp, error) {
+	path := fmt.Sprintf("%s/api/%s/search", m.url, app)
+	body,_ := proto.Marshal(req)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() == 400 {
+		return nil, ErrInvalid
+	}
+	if response.StatusCode() != 200 {
+		return nil, fmt.Errorf("MessageHttp.Search response.StatusCode() = %d, want 200", response.StatusCode())
+	}
+	var resp rpc.SearchMessageResp
+	_ = proto.Unmarshal(response.Body(), &resp)
+	return &resp, nil
+}
+
+func (m * MessageHttp) GetMessageIndex(ctx context.Context, app string, req *rpc.GetOfflineMessageIndexReq) (*rpc.GetOfflineMessageIndexResp, error) {
 	path := fmt.Sprintf("%s/api/%s/offline/index", m.url, app)
 	body,_ := proto.Marshal(req)
-	response, err := m.Req().SetBody(body).Get(path)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
 	if err != nil  {
 		return nil, err
 	}
@@ -106,10 +255,10 @@ func (m * MessageHttp) GetMessageIndex(app string, req *rpc.GetOfflineMessageInd
 	return &resp,nil
 }
 
-func (m*MessageHttp) GetMessageContent(app string, req *rpc.GetOfflineMessageContentReq) (*rpc.GetOfflineMessageContentResp, error) {
+func (m*MessageHttp) GetMessageContent(ctx context.Context, app string, req *rpc.GetOfflineMessageContentReq) (*rpc.GetOfflineMessageContentResp, error) {
 	path := fmt.Sprintf("%s/api/%s/offline/content", m.url, app)
 	body,_ := proto.Marshal(req)
-	response, err := m.Req().SetBody(body).Get(path)
+	response, err := m.Req(ctx).SetBody(body).Post(path)
 	if err != nil {
 		return nil, err
 	}
@@ -122,9 +271,9 @@ func (m*MessageHttp) GetMessageContent(app string, req *rpc.GetOfflineMessageCon
 }
 
 
-func (m *MessageHttp) Req() *resty.Request {
+func (m *MessageHttp) Req(ctx context.Context) *resty.Request {
 	if m.srv == nil {
-		return m.cli.R()
+		return m.cli.R().SetContext(ctx)
 	}
-	return m.cli.R().SetSRV(m.srv)
+	return m.cli.R().SetContext(ctx).SetSRV(m.srv)
 }
@@ -27,7 +27,10 @@ type Config struct {
 	RedisAddrs    string
 	BaseDb        string
 	MessageDb     string
-	LogLevel      string `default:"INFO"`
+	LogLevel      string        `default:"INFO"`
+	RecallWindow  time.Duration `default:"2m"` // 消息发送之后可以撤回的时间
+	// 群成员数超过这个值之后使用读扩散保存群消息，0表示总是写扩散
+	LargeGroupThreshold int `default:"500"`
 }
 
 func (c Config) String() string {
@@ -21,9 +21,22 @@ type MessageIndex struct {
 type MessageCount struct {
 	ID          int64   `gorm:"primarykey"`
 	Type        byte    `gorm:"default:0"`
-	Body        string  `gorm:"size:5000;not null"`
+	Body        string  `gorm:"size:5000;not null;index:idx_body,class:FULLTEXT,option:WITH PARSER ngram"`
 	Extra       string  `gorm:"size:500"`
 	SendTime    int64   `gorm:"index"`
+	Recalled    bool    `gorm:"default:false;comment:已撤回"`
+	EditedAt    int64   `gorm:"default:0;comment:最后一次修改的时间"`
+	Revision    int32   `gorm:"default:0;comment:修改的次数"`
+}
+
+// MessageRevision 消息被修改之前的版本
+type MessageRevision struct {
+	ID        int64  `gorm:"primarykey"`
+	MessageID int64  `gorm:"index;not null;comment:关联消息内容表中的ID"`
+	Revision  int32  `gorm:"not null;comment:这个版本的序号，原始消息为0"`
+	Body      string `gorm:"size:5000;not null"`
+	Extra     string `gorm:"size:500"`
+	EditedAt  int64  `gorm:"not null;comment:这个版本被替换的时间"`
 }
 
 type User struct {
@@ -42,6 +55,14 @@ type Group struct {
 	Owner        string `gorm:"size:60"`
 	Avatar       string `gorm:"size:200"`
 	Introduction string `gorm:"size:300"`
+	JoinMode     byte   `gorm:"not null;default:0;comment:0自由加入 1需要审批 2只能邀请"`
+	Muted        bool   `gorm:"not null;default:false;comment:全群禁言"`
+	// 群公告
+	Announcement   string `gorm:"size:1000"`
+	AnnouncementBy string `gorm:"size:60"`
+	AnnouncementAt int64  `gorm:"default:0"`
+	// ReadDiffusion 成员数超过阈值之后改为读扩散，消息只写入GroupTimeline
+	ReadDiffusion bool `gorm:"not null;default:false"`
 }
 
 // GroupMember GroupMember
@@ -50,4 +71,88 @@ type GroupMember struct {
 	Account string `gorm:"uniqueIndex:uni_gp_acc;size:60"`
 	Group   string `gorm:"uniqueIndex:uni_gp_acc;index;size:30"`
 	Alias   string `gorm:"size:30"`
-}
\ No newline at end of file
+	Role    byte   `gorm:"not null;default:0;comment:0成员 1管理员 2群主"`
+	// MutedUntil 禁言结束的时间，单位秒
+	MutedUntil int64 `gorm:"not null;default:0"`
+}
+
+// GroupJoinRequest 等待群主或者管理员审批的入群申请
+type GroupJoinRequest struct {
+	Model
+	Group   string `gorm:"index;size:30;not null"`
+	Account string `gorm:"size:60;not null"`
+	Inviter string `gorm:"size:60;comment:邀请人，主动申请时为空"`
+	Status  byte   `gorm:"not null;default:0;comment:0等待审批 1通过 2拒绝"`
+}
+
+// GroupJoinRequest 的状态
+const (
+	GroupJoinPending  byte = 0
+	GroupJoinApproved byte = 1
+	GroupJoinRejected byte = 2
+)
+
+// ReadCursor 账号在一个会话中已读到的位置
+type ReadCursor struct {
+	ID        int64  `gorm:"primarykey"`
+	Account   string `gorm:"uniqueIndex:uni_acc_dest;size:60;not null"`
+	Dest      string `gorm:"uniqueIndex:uni_acc_dest;size:60;not null;comment:单聊为对方账号，群聊为群ID"`
+	Group     bool   `gorm:"uniqueIndex:uni_acc_dest;not null;default:false"`
+	MessageID int64  `gorm:"not null;comment:已读到的消息"`
+	SendTime  int64  `gorm:"not null;comment:已读消息的发送时间"`
+	UpdatedAt time.Time
+}
+
+// GroupTimeline 读扩散的群中每条消息一行，成员的已读位置保存在ReadCursor中
+type GroupTimeline struct {
+	ID        int64  `gorm:"primarykey"`
+	Group     string `gorm:"index:idx_group_time,priority:1;size:30;not null"`
+	Sender    string `gorm:"size:60;not null"`
+	MessageID int64  `gorm:"uniqueIndex;not null;comment:关联消息内容表中的ID"`
+	SendTime  int64  `gorm:"index:idx_group_time,priority:2;not null"`
+}
+
+// Conversation 账号的会话列表，写消息时更新
+type Conversation struct {
+	ID            int64  `gorm:"primarykey"`
+	Account       string `gorm:"uniqueIndex:uni_acc_conv;index:idx_acc_time,priority:1;size:60;not null"`
+	Dest          string `gorm:"uniqueIndex:uni_acc_conv;index;size:60;not null;comment:单聊为对方账号，群聊为群ID"`
+	Group         bool   `gorm:"uniqueIndex:uni_acc_conv;not null;default:false"`
+	LastMessageID int64  `gorm:"not null"`
+	LastSender    string `gorm:"size:60;not null"`
+	LastType      byte   `gorm:"default:0"`
+	LastBody      string `gorm:"size:300;comment:最后一条消息的预览"`
+	LastSendTime  int64  `gorm:"index:idx_acc_time,priority:2;not null"`
+	Unread        int32  `gorm:"not null;default:0"`
+	Pinned        bool   `gorm:"not null;default:false"`
+	Muted         bool   `gorm:"not null;default:false"`
+	UpdatedAt     time.Time
+}
+
+// Contact 账号对另一个账号的关系，每个方向一行，双方的Friend都为true时才是好友
+type Contact struct {
+	Model
+	App     string `gorm:"uniqueIndex:uni_app_acc_peer;size:30"`
+	Account string `gorm:"uniqueIndex:uni_app_acc_peer;size:60;not null"`
+	Peer    string `gorm:"uniqueIndex:uni_app_acc_peer;size:60;not null"`
+	Remark  string `gorm:"size:30;comment:备注"`
+	Friend  bool   `gorm:"not null;default:false"`
+	Blocked bool   `gorm:"not null;default:false;comment:Account拉黑了Peer"`
+}
+
+// FriendRequestStatus 好友请求的状态
+const (
+	FriendRequestPending  byte = 0
+	FriendRequestAccepted byte = 1
+	FriendRequestRejected byte = 2
+)
+
+// FriendRequest 好友请求
+type FriendRequest struct {
+	Model
+	App       string `gorm:"index;size:30"`
+	Requester string `gorm:"index;size:60;not null;comment:发起请求的账号"`
+	Target    string `gorm:"index;size:60;not null;comment:被请求的账号"`
+	Message   string `gorm:"size:200"`
+	Status    byte   `gorm:"not null;default:0"`
+}
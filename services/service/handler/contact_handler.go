@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/kataras/iris/v12"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"kingim/services/service/database"
+	"kingim/wire/rpc"
+)
+
+var (
+	ErrBlocked        = errors.New("blocked by the account")
+	ErrAlreadyFriends = errors.New("already friends")
+)
+
+func (h *ServiceHandle) ContactRequest(c iris.Context) {
+	var req rpc.FriendRequestReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.From == "" || req.To == "" || req.From == req.To {
+		c.StopWithText(iris.StatusBadRequest, "invalid from or to")
+		return
+	}
+	id, err := h.contactRequest(c.Params().Get("app"), &req)
+	if err == ErrBlocked {
+		c.StopWithText(iris.StatusForbidden, err.Error())
+		return
+	}
+	if err == ErrAlreadyFriends {
+		c.StopWithText(iris.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	_, _ = c.Negotiate(&rpc.FriendRequestResp{RequestId: id})
+}
+
+// contactRequest 创建好友请求，已经有等待处理的请求时只更新附言
+func (h *ServiceHandle) contactRequest(app string, req *rpc.FriendRequestReq) (int64, error) {
+	rel, err := h.relation(app, req.From, req.To)
+	if err != nil {
+		return 0, err
+	}
+	if rel.Blocked {
+		return 0, ErrBlocked
+	}
+	if rel.Friend {
+		return 0, ErrAlreadyFriends
+	}
+	var pending database.FriendRequest
+	err = h.BaseDb.Where("app=? and requester=? and target=? and status=?", app, req.From, req.To, database.FriendRequestPending).First(&pending).Error
+	if err == nil {
+		return pending.ID, h.BaseDb.Model(&pending).Update("message", req.Message).Error
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	fr := &database.FriendRequest{
+		Model:     database.Model{ID: h.Idgen.Next().Int64()},
+		App:       app,
+		Requester: req.From,
+		Target:    req.To,
+		Message:   req.Message,
+		Status:    database.FriendRequestPending,
+	}
+	if err = h.BaseDb.Create(fr).Error; err != nil {
+		return 0, err
+	}
+	return fr.ID, nil
+}
+
+func (h *ServiceHandle) ContactHandleRequest(c iris.Context) {
+	var req rpc.HandleFriendRequestReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	app := c.Params().Get("app")
+	var fr database.FriendRequest
+	err := h.BaseDb.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id=? and app=? and target=? and status=?", req.RequestId, app, req.Account, database.FriendRequestPending).First(&fr).Error
+		if err != nil {
+			return err
+		}
+		status := database.FriendRequestRejected
+		if req.Accept {
+			status = database.FriendRequestAccepted
+		}
+		if err = tx.Model(&fr).Update("status", status).Error; err != nil {
+			return err
+		}
+		if !req.Accept {
+			return nil
+		}
+		contacts := []database.Contact{
+			{Model: database.Model{ID: h.Idgen.Next().Int64()}, App: app, Account: fr.Requester, Peer: fr.Target, Friend: true},
+			{Model: database.Model{ID: h.Idgen.Next().Int64()}, App: app, Account: fr.Target, Peer: fr.Requester, Friend: true},
+		}
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{"friend", "updated_at"}),
+		}).Create(&contacts).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.StopWithText(iris.StatusNotFound, "friend request not found")
+		return
+	}
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	_, _ = c.Negotiate(&rpc.HandleFriendRequestResp{From: fr.Requester})
+}
+
+// ContactRequests 等待account处理的好友请求
+func (h *ServiceHandle) ContactRequests(c iris.Context) {
+	app := c.Params().Get("app")
+	account := c.Params().Get("account")
+	var list []database.FriendRequest
+	err := h.BaseDb.Where("app=? and target=? and status=?", app, account, database.FriendRequestPending).Order("created_at desc").Limit(100).Find(&list).Error
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	var resp = &rpc.FriendRequestsResp{List: make([]*rpc.FriendRequest, len(list))}
+	for i, fr := range list {
+		resp.List[i] = &rpc.FriendRequest{
+			Id:        fr.ID,
+			From:      fr.Requester,
+			Message:   fr.Message,
+			CreatedAt: fr.CreatedAt.Unix(),
+		}
+	}
+	_, _ = c.Negotiate(resp)
+}
+
+// ContactList 好友和拉黑的账号
+func (h *ServiceHandle) ContactList(c iris.Context) {
+	app := c.Params().Get("app")
+	account := c.Params().Get("account")
+	var list []database.Contact
+	err := h.BaseDb.Where("app=? and account=? and (friend=? or blocked=?)", app, account, true, true).Order("created_at asc").Find(&list).Error
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	var resp = &rpc.ContactsResp{List: make([]*rpc.Contact, len(list))}
+	for i, ct := range list {
+		resp.List[i] = &rpc.Contact{
+			Account:   ct.Peer,
+			Remark:    ct.Remark,
+			Friend:    ct.Friend,
+			Blocked:   ct.Blocked,
+			CreatedAt: ct.CreatedAt.Unix(),
+		}
+	}
+	_, _ = c.Negotiate(resp)
+}
+
+// ContactRemove 双方都不再是好友
+func (h *ServiceHandle) ContactRemove(c iris.Context) {
+	var req rpc.ContactReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	err := h.BaseDb.Model(&database.Contact{}).
+		Where("app=? and ((account=? and peer=?) or (account=? and peer=?))", c.Params().Get("app"), req.Account, req.Peer, req.Peer, req.Account).
+		Update("friend", false).Error
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (h *ServiceHandle) ContactBlock(c iris.Context) {
+	var req rpc.BlockContactReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == "" || req.Peer == "" || req.Account == req.Peer {
+		c.StopWithText(iris.StatusBadRequest, "invalid account or peer")
+		return
+	}
+	err := h.upsertContact(c.Params().Get("app"), req.Account, req.Peer, "blocked", req.Blocked)
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (h *ServiceHandle) ContactRemark(c iris.Context) {
+	var req rpc.RemarkContactReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == "" || req.Peer == "" || req.Account == req.Peer {
+		c.StopWithText(iris.StatusBadRequest, "invalid account or peer")
+		return
+	}
+	err := h.upsertContact(c.Params().Get("app"), req.Account, req.Peer, "remark", req.Remark)
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+}
+
+// upsertContact 设置account对peer关系中的一个字段，关系不存在时创建
+func (h *ServiceHandle) upsertContact(app, account, peer, column string, value interface{}) error {
+	ct := map[string]interface{}{
+		"id":         h.Idgen.Next().Int64(),
+		"app":        app,
+		"account":    account,
+		"peer":       peer,
+		"created_at": gorm.Expr("NOW()"),
+		"updated_at": gorm.Expr("NOW()"),
+		column:       value,
+	}
+	return h.BaseDb.Model(&database.Contact{}).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{column, "updated_at"}),
+	}).Create(ct).Error
+}
+
+func (h *ServiceHandle) ContactRelation(c iris.Context) {
+	rel, err := h.relation(c.Params().Get("app"), c.Params().Get("account"), c.Params().Get("peer"))
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	_, _ = c.Negotiate(rel)
+}
+
+// relation app中account和peer之间的关系，双方都有Friend标记时才是好友
+func (h *ServiceHandle) relation(app, account, peer string) (*rpc.RelationResp, error) {
+	var list []database.Contact
+	err := h.BaseDb.Where("app=? and ((account=? and peer=?) or (account=? and peer=?))", app, account, peer, peer, account).Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	var rel rpc.RelationResp
+	friends := 0
+	for _, ct := range list {
+		if ct.Friend {
+			friends++
+		}
+		if ct.Account == peer && ct.Blocked {
+			rel.Blocked = true
+		}
+	}
+	rel.Friend = friends == 2
+	return &rel, nil
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"kingim/wire/rpc"
+)
+
+var contactColumns = []string{"id", "app", "account", "peer", "remark", "friend", "blocked"}
+
+func TestRelation(t *testing.T) {
+	h, mock := newTestHandle(t)
+	// 只读取同一个app中的关系
+	mock.ExpectQuery("FROM `t_contact` WHERE app=\\? and \\(\\(account=\\? and peer=\\?\\) or \\(account=\\? and peer=\\?\\)\\)").
+		WithArgs("app1", "test1", "test2", "test2", "test1").
+		WillReturnRows(sqlmock.NewRows(contactColumns).
+			AddRow(1, "app1", "test1", "test2", "", true, false).
+			AddRow(2, "app1", "test2", "test1", "", true, true))
+
+	rel, err := h.relation("app1", "test1", "test2")
+	assert.Nil(t, err)
+	assert.True(t, rel.Friend)
+	assert.True(t, rel.Blocked)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestContactRequest_Blocked(t *testing.T) {
+	h, mock := newTestHandle(t)
+	mock.ExpectQuery("FROM `t_contact` WHERE app=\\?").WithArgs("app1", "test1", "test2", "test2", "test1").
+		WillReturnRows(sqlmock.NewRows(contactColumns).AddRow(2, "app1", "test2", "test1", "", false, true))
+
+	_, err := h.contactRequest("app1", &rpc.FriendRequestReq{From: "test1", To: "test2"})
+	assert.Equal(t, ErrBlocked, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"sort"
+
+	"github.com/kataras/iris/v12"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"kingim/services/service/database"
+	"kingim/wire"
+	"kingim/wire/rpc"
+)
+
+func (h *ServiceHandle) ConversationList(c iris.Context) {
+	var req rpc.ConversationListReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == "" {
+		c.StopWithText(iris.StatusBadRequest, "account is required")
+		return
+	}
+	limit := int(req.Limit)
+	if limit <= 0 || limit > wire.ConversationMaxPerPage {
+		limit = wire.ConversationMaxPerPage
+	}
+	// 读扩散的群的会话不随消息更新，和置顶的会话一样都在第一页返回
+	groups, err := h.timelineGroups(req.Account)
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	var list, timeline []database.Conversation
+	// 置顶的会话都在第一页返回，之后按最后一条消息的时间倒序分页
+	if req.Cursor == 0 {
+		err = h.MessageDb.Where("account=? and pinned=?", req.Account, true).Order("last_send_time desc").Find(&list).Error
+		if err != nil {
+			c.StopWithError(iris.StatusInternalServerError, err)
+			return
+		}
+		if len(groups) > 0 {
+			err = h.MessageDb.Where("account=? and pinned=? and `group`=? and dest in ?", req.Account, false, true, groupIds(groups)).Find(&timeline).Error
+			if err != nil {
+				c.StopWithError(iris.StatusInternalServerError, err)
+				return
+			}
+			// 排序之前先从时间线中读取最后一条消息
+			convs := append(list, timeline...)
+			if err = h.fillTimelines(req.Account, groups, convs); err != nil {
+				c.StopWithError(iris.StatusInternalServerError, err)
+				return
+			}
+			list, timeline = convs[:len(list)], convs[len(list):]
+		}
+	}
+	var page []database.Conversation
+	tx := h.MessageDb.Where("account=? and pinned=?", req.Account, false)
+	if len(groups) > 0 {
+		tx = tx.Where("not (`group`=? and dest in ?)", true, groupIds(groups))
+	}
+	if req.Cursor > 0 {
+		tx = tx.Where("last_send_time<?", req.Cursor)
+	}
+	if err = tx.Order("last_send_time desc").Limit(limit).Find(&page).Error; err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	var resp rpc.ConversationListResp
+	if len(page) == limit {
+		resp.NextCursor = page[len(page)-1].LastSendTime
+	}
+	if len(timeline) > 0 {
+		page = append(timeline, page...)
+		sort.SliceStable(page, func(i, j int) bool {
+			return page[i].LastSendTime > page[j].LastSendTime
+		})
+	}
+	list = append(list, page...)
+	resp.List = make([]*rpc.Conversation, len(list))
+	for i, conv := range list {
+		resp.List[i] = &rpc.Conversation{
+			Dest:          conv.Dest,
+			Group:         conv.Group,
+			LastMessageId: conv.LastMessageID,
+			LastSender:    conv.LastSender,
+			LastType:      int32(conv.LastType),
+			LastBody:      conv.LastBody,
+			LastSendTime:  conv.LastSendTime,
+			Unread:        conv.Unread,
+			Pinned:        conv.Pinned,
+			Muted:         conv.Muted,
+		}
+	}
+	_, _ = c.Negotiate(&resp)
+}
+
+func (h *ServiceHandle) ConversationUpdate(c iris.Context) {
+	var req rpc.ConversationUpdateReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == "" || req.Dest == "" {
+		c.StopWithText(iris.StatusBadRequest, "account and dest are required")
+		return
+	}
+	// 只更新请求中设置了的字段，避免置顶时把免打扰清掉
+	updates := make(map[string]interface{}, 2)
+	if req.Pinned != nil {
+		updates["pinned"] = req.GetPinned()
+	}
+	if req.Muted != nil {
+		updates["muted"] = req.GetMuted()
+	}
+	if len(updates) == 0 {
+		c.StopWithText(iris.StatusBadRequest, "pinned or muted is required")
+		return
+	}
+	result := h.MessageDb.Model(&database.Conversation{}).
+		Where("account=? and dest=? and `group`=?", req.Account, req.Dest, req.Group).
+		Updates(updates)
+	if result.Error != nil {
+		c.StopWithError(iris.StatusInternalServerError, result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		h.MessageDb.Model(&database.Conversation{}).Where("account=? and dest=? and `group`=?", req.Account, req.Dest, req.Group).Count(&count)
+		if count == 0 {
+			c.StopWithText(iris.StatusNotFound, "conversation not found")
+			return
+		}
+	}
+}
+
+// newConversation 构造account的会话在收到一条消息之后的状态
+func newConversation(id int64, account, dest string, group bool, messageId int64, req *rpc.InsertMessageReq) database.Conversation {
+	conv := database.Conversation{
+		ID:            id,
+		Account:       account,
+		Dest:          dest,
+		Group:         group,
+		LastMessageID: messageId,
+		LastSender:    req.Sender,
+		LastType:      byte(req.Message.Type),
+		LastSendTime:  req.SendTime,
+	}
+	// 只有文本消息保存预览，其它类型由客户端根据类型显示
+	if req.Message.Type == wire.MessageTypeText {
+		conv.LastBody = preview(req.Message.Body)
+	}
+	if account != req.Sender {
+		conv.Unread = 1
+	}
+	return conv
+}
+
+func preview(body string) string {
+	runes := []rune(body)
+	if len(runes) <= wire.ConversationPreviewLength {
+		return body
+	}
+	return string(runes[:wire.ConversationPreviewLength])
+}
+
+// upsertConversations 更新会话的最后一条消息和未读数，乱序到达的旧消息只增加未读数
+func upsertConversations(tx *gorm.DB, convs []database.Conversation) error {
+	if len(convs) == 0 {
+		return nil
+	}
+	newer := "VALUES(last_send_time) >= last_send_time"
+	return tx.Clauses(clause.OnConflict{
+		// last_send_time 必须最后更新，前面的条件使用的是旧的值
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "last_message_id"}, Value: gorm.Expr("IF(" + newer + ", VALUES(last_message_id), last_message_id)")},
+			{Column: clause.Column{Name: "last_sender"}, Value: gorm.Expr("IF(" + newer + ", VALUES(last_sender), last_sender)")},
+			{Column: clause.Column{Name: "last_type"}, Value: gorm.Expr("IF(" + newer + ", VALUES(last_type), last_type)")},
+			{Column: clause.Column{Name: "last_body"}, Value: gorm.Expr("IF(" + newer + ", VALUES(last_body), last_body)")},
+			{Column: clause.Column{Name: "unread"}, Value: gorm.Expr("unread + VALUES(unread)")},
+			{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("VALUES(updated_at)")},
+			{Column: clause.Column{Name: "last_send_time"}, Value: gorm.Expr("GREATEST(last_send_time, VALUES(last_send_time))")},
+		},
+	}).CreateInBatches(&convs, 500).Error
+}
+
+// resetUnread 已读位置前进之后，重新统计会话在索引中的未读数
+func (h *ServiceHandle) resetUnread(req *rpc.ReadMessageReq, readTime int64) error {
+	var count int64
+	tx := h.MessageDb.Model(&database.MessageIndex{}).Where("account_a=? and direction=0 and send_time>?", req.Account, readTime)
+	if req.Group {
+		tx = tx.Where("`group`=?", req.Dest)
+	} else {
+		tx = tx.Where("account_b=? and `group`=''", req.Dest)
+	}
+	if err := tx.Count(&count).Error; err != nil {
+		return err
+	}
+	// 读扩散的群的时间线中的未读数在读取会话列表时统计
+	return h.MessageDb.Model(&database.Conversation{}).
+		Where("account=? and dest=? and `group`=?", req.Account, req.Dest, req.Group).
+		Update("unread", count).Error
+}
+
+// fillTimelines 读扩散的群的会话从时间线中读取最后一条消息，未读数加上时间线中晚于已读位置的消息数
+func (h *ServiceHandle) fillTimelines(account string, groups []timelineGroup, list []database.Conversation) error {
+	lasts, err := h.timelineLasts(groups)
+	if err != nil {
+		return err
+	}
+	unreads, err := h.timelineUnreads(account, groups, 0)
+	if err != nil {
+		return err
+	}
+	lastMap := make(map[string]*database.GroupTimeline, len(lasts))
+	ids := make([]int64, len(lasts))
+	for i := range lasts {
+		lastMap[lasts[i].Group] = &lasts[i]
+		ids[i] = lasts[i].MessageID
+	}
+	var contents []database.MessageCount
+	if len(ids) > 0 {
+		if err = h.MessageDb.Select("id", "type", "body", "recalled").Where(ids).Find(&contents).Error; err != nil {
+			return err
+		}
+	}
+	contentMap := make(map[int64]*database.MessageCount, len(contents))
+	for i := range contents {
+		contentMap[contents[i].ID] = &contents[i]
+	}
+	unreadMap := make(map[string]int32, len(unreads))
+	for _, u := range unreads {
+		unreadMap[u.Dest] = u.Count
+	}
+	for i := range list {
+		conv := &list[i]
+		if !conv.Group {
+			continue
+		}
+		conv.Unread += unreadMap[conv.Dest]
+		last, ok := lastMap[conv.Dest]
+		if !ok || last.SendTime < conv.LastSendTime {
+			continue
+		}
+		content, ok := contentMap[last.MessageID]
+		if !ok {
+			continue
+		}
+		conv.LastMessageID = last.MessageID
+		conv.LastSender = last.Sender
+		conv.LastType = content.Type
+		conv.LastSendTime = last.SendTime
+		conv.LastBody = ""
+		if content.Type == wire.MessageTypeText && !content.Recalled {
+			conv.LastBody = preview(content.Body)
+		}
+	}
+	return nil
+}
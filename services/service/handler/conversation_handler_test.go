@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kingim/wire/rpc"
+)
+
+var conversationColumns = []string{"id", "account", "dest", "group", "last_message_id", "last_sender", "last_type", "last_body", "last_send_time", "unread", "pinned", "muted"}
+
+func TestConversationList_Cursor(t *testing.T) {
+	h, mock := newTestHandle(t)
+	joined := time.Unix(100, 0)
+	mock.ExpectQuery("FROM t_group_member gm JOIN t_group g").WithArgs("test1", true).
+		WillReturnRows(sqlmock.NewRows([]string{"group", "created_at"}).AddRow("g1", joined))
+	// 后面的页中没有置顶的会话，读扩散的群的会话不参与分页
+	mock.ExpectQuery("FROM `t_conversation` WHERE \\(account=\\? and pinned=\\?\\) AND \\(not \\(`group`=\\? and dest in \\(\\?\\)\\)\\) AND last_send_time<\\? ORDER BY last_send_time desc LIMIT 2").
+		WithArgs("test1", false, true, "g1", 500).
+		WillReturnRows(sqlmock.NewRows(conversationColumns).
+			AddRow(1, "test1", "test2", false, 11, "test2", 1, "hi", 400, 1, false, false).
+			AddRow(2, "test1", "g2", true, 12, "test3", 1, "yo", 300, 0, false, true))
+
+	var resp rpc.ConversationListResp
+	code := serve(t, h.ConversationList, &rpc.ConversationListReq{Account: "test1", Cursor: 500, Limit: 2}, &resp)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(300), resp.NextCursor)
+	require.Equal(t, 2, len(resp.List))
+	assert.Equal(t, "test2", resp.List[0].Dest)
+	assert.Equal(t, "g2", resp.List[1].Dest)
+	assert.True(t, resp.List[1].Muted)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestConversationList_Timeline(t *testing.T) {
+	h, mock := newTestHandle(t)
+	joined := time.Unix(0, 50)
+	mock.ExpectQuery("FROM t_group_member gm JOIN t_group g").
+		WillReturnRows(sqlmock.NewRows([]string{"group", "created_at"}).AddRow("g1", joined))
+	mock.ExpectQuery("FROM `t_conversation` WHERE account=\\? and pinned=\\? ORDER BY").WithArgs("test1", true).
+		WillReturnRows(sqlmock.NewRows(conversationColumns).
+			AddRow(1, "test1", "test2", false, 11, "test2", 1, "pinned", 200, 0, true, false))
+	mock.ExpectQuery("FROM `t_conversation` WHERE account=\\? and pinned=\\? and `group`=\\? and dest in \\(\\?\\)").
+		WithArgs("test1", false, true, "g1").
+		WillReturnRows(sqlmock.NewRows(conversationColumns).
+			AddRow(2, "test1", "g1", true, 0, "", 0, "", 0, 1, false, false))
+	// 最后一条消息和未读数只能是加入之后的
+	mock.ExpectQuery("FROM t_group_timeline gt JOIN \\(SELECT `group`,MAX\\(send_time\\) AS send_time FROM `t_group_timeline` WHERE \\(\\(`group`=\\? and send_time>\\?\\)\\) GROUP BY `group`\\) l").
+		WithArgs("g1", joined.UnixNano()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "group", "sender", "message_id", "send_time"}).AddRow(9, "g1", "test5", 900, 600))
+	mock.ExpectQuery("FROM t_group_timeline gt LEFT JOIN t_read_cursor rc").
+		WithArgs("test1", "test1", "g1", joined.UnixNano()).
+		WillReturnRows(sqlmock.NewRows([]string{"dest", "group", "count", "read_message_id"}).AddRow("g1", true, 3, 800))
+	mock.ExpectQuery("FROM `t_message_count` WHERE `t_message_count`.`id` = \\?").WithArgs(900).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "body", "recalled"}).AddRow(900, 1, "hello", false))
+	mock.ExpectQuery("FROM `t_conversation` WHERE \\(account=\\? and pinned=\\?\\) AND \\(not").
+		WillReturnRows(sqlmock.NewRows(conversationColumns).
+			AddRow(3, "test1", "test3", false, 13, "test3", 1, "later", 400, 0, false, false))
+
+	var resp rpc.ConversationListResp
+	code := serve(t, h.ConversationList, &rpc.ConversationListReq{Account: "test1", Limit: 2}, &resp)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(0), resp.NextCursor)
+	require.Equal(t, 3, len(resp.List))
+	assert.Equal(t, "test2", resp.List[0].Dest)
+	// 读扩散的群按时间线中最后一条消息的时间排序
+	g1 := resp.List[1]
+	assert.Equal(t, "g1", g1.Dest)
+	assert.Equal(t, int64(900), g1.LastMessageId)
+	assert.Equal(t, "test5", g1.LastSender)
+	assert.Equal(t, "hello", g1.LastBody)
+	assert.Equal(t, int64(600), g1.LastSendTime)
+	assert.Equal(t, int32(4), g1.Unread)
+	assert.Equal(t, "test3", resp.List[2].Dest)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestConversationUpdate(t *testing.T) {
+	h, mock := newTestHandle(t)
+	pinned := true
+	// 只更新请求中设置了的字段
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `t_conversation` SET `pinned`=\\?,`updated_at`=\\? WHERE").
+		WithArgs(true, sqlmock.AnyArg(), "test1", "test2", false).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	code := serve(t, h.ConversationUpdate, &rpc.ConversationUpdateReq{Account: "test1", Dest: "test2", Pinned: &pinned}, nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	code = serve(t, h.ConversationUpdate, &rpc.ConversationUpdateReq{Account: "test1", Dest: "test2"}, nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -1,14 +1,26 @@
 package handler
 
 import (
+	"time"
+	"unicode/utf8"
+
 	"github.com/bwmarrin/snowflake"
 	"github.com/kataras/iris/v12"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"kingim/services/service/database"
+	"kingim/wire"
 	"kingim/wire/rpc"
 )
 
+var (
+	ErrGroupNotFound   = errors.New("group not found")
+	ErrNotGroupMember  = errors.New("not a member of the group")
+	ErrGroupPermission = errors.New("permission denied")
+	ErrOwnerCannotQuit = errors.New("the owner can not quit, transfer or dissolve the group instead")
+)
+
 func (h*ServiceHandle) GroupCreate(c iris.Context) {
 	app := c.Params().Get("app")
 	var req rpc.CreateGroupReq
@@ -17,6 +29,10 @@ func (h*ServiceHandle) GroupCreate(c iris.Context) {
 		return
 	}
 	req.App = app
+	if req.Owner == "" {
+		c.StopWithText(iris.StatusBadRequest, "owner is required")
+		return
+	}
 	groupId, err := h.groupCreate(&req)
 	if err != nil {
 		c.StopWithError(iris.StatusInternalServerError, err)
@@ -41,13 +57,22 @@ func (h*ServiceHandle) groupCreate(req *rpc.CreateGroupReq) (snowflake.ID, error
 		Owner: req.Owner,
 		Introduction: req.Introduction,
 	}
-	members := make([]database.GroupMember, len(req.Members))
-	for i, user := range req.Members {
-		members[i] = database.GroupMember{
+	// 群主总是群成员
+	members := []database.GroupMember{{
+		Model: database.Model{ID: h.Idgen.Next().Int64()},
+		Account: req.Owner,
+		Group: groupId.Base36(),
+		Role: wire.GroupRoleOwner,
+	}}
+	for _, user := range req.Members {
+		if user == req.Owner {
+			continue
+		}
+		members = append(members, database.GroupMember{
 			Model: database.Model{ID: h.Idgen.Next().Int64()},
 			Account: user,
 			Group: groupId.Base36(),
-		}
+		})
 	}
 	err := h.BaseDb.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(g).Error; err != nil {
@@ -70,33 +95,440 @@ func (h*ServiceHandle) GroupJoin(c iris.Context) {
 		c.StopWithError(iris.StatusBadRequest, err)
 		return
 	}
-	gm := &database.GroupMember{
-		Model: database.Model{ID: h.Idgen.Next().Int64()},
-		Account: req.Account,
-		Group: req.GroupId,
+	if req.Account == "" || req.GroupId == "" {
+		c.StopWithText(iris.StatusBadRequest, "account and group_id are required")
+		return
 	}
-	err := h.BaseDb.Create(gm).Error
+	resp, err := h.groupJoin(&req)
 	if err != nil {
-		c.StopWithError(iris.StatusInternalServerError, err)
+		stopWithGroupError(c, err)
 		return
 	}
+	_, _ = c.Negotiate(resp)
+}
+
+// groupJoin 按群的加入方式直接加入或者创建入群申请，群主和管理员邀请时不需要审批
+func (h*ServiceHandle) groupJoin(req *rpc.JoinGroupReq) (*rpc.JoinGroupResp, error) {
+	group, err := h.findGroup(req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	direct := group.JoinMode == wire.GroupJoinOpen
+	var inviter string
+	if req.Operator != "" && req.Operator != req.Account {
+		inviter = req.Operator
+		role, err := h.checkRole(h.BaseDb, req.GroupId, req.Operator, wire.GroupRoleMember)
+		if err != nil {
+			return nil, err
+		}
+		if role >= wire.GroupRoleAdmin {
+			direct = true
+		}
+	}
+	if direct {
+		return &rpc.JoinGroupResp{}, h.addMember(h.BaseDb, req.GroupId, req.Account)
+	}
+	if group.JoinMode == wire.GroupJoinInviteOnly {
+		return nil, ErrGroupPermission
+	}
+	if _, err = h.groupRole(h.BaseDb, req.GroupId, req.Account); err == nil {
+		// 已经在群中
+		return &rpc.JoinGroupResp{}, nil
+	}
+	jr := &database.GroupJoinRequest{
+		Model: database.Model{ID: h.Idgen.Next().Int64()},
+		Group: req.GroupId,
+		Account: req.Account,
+		Inviter: inviter,
+	}
+	if err = h.BaseDb.Create(jr).Error; err != nil {
+		return nil, err
+	}
+	return &rpc.JoinGroupResp{Pending: true, RequestId: jr.ID}, nil
 }
 
+// GroupQuit 主动退出，群主需要先转让或者解散群
 func (h*ServiceHandle) GroupQuit(c iris.Context) {
 	var req rpc.QuitGroupReq
 	if err := c.ReadBody(&req); err != nil {
 		c.StopWithError(iris.StatusBadRequest,err)
 		return
 	}
-	g := &database.GroupMember{
-		Account: req.Account,
-		Group: req.GroupId,
+	role, err := h.checkRole(h.BaseDb, req.GroupId, req.Account, wire.GroupRoleMember)
+	if err == nil && role == wire.GroupRoleOwner {
+		err = ErrOwnerCannotQuit
+	}
+	if err == nil {
+		err = h.removeMember(h.BaseDb, req.GroupId, req.Account)
+	}
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+}
+
+// GroupKick 群主可以移除任何成员，管理员只能移除普通成员
+func (h*ServiceHandle) GroupKick(c iris.Context) {
+	var req rpc.KickGroupMemberReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	err := h.BaseDb.Transaction(func(tx *gorm.DB) error {
+		role, err := h.checkRole(tx, req.GroupId, req.Operator, wire.GroupRoleAdmin)
+		if err != nil {
+			return err
+		}
+		target, err := h.groupRole(tx, req.GroupId, req.Account)
+		if err != nil {
+			return err
+		}
+		if target >= role {
+			return ErrGroupPermission
+		}
+		return h.removeMember(tx, req.GroupId, req.Account)
+	})
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+}
+
+// GroupSetRole 只有群主可以设置或者取消管理员
+func (h*ServiceHandle) GroupSetRole(c iris.Context) {
+	var req rpc.SetGroupRoleReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == req.Operator {
+		c.StopWithText(iris.StatusBadRequest, "can not change the role of yourself")
+		return
+	}
+	err := h.BaseDb.Transaction(func(tx *gorm.DB) error {
+		if _, err := h.checkRole(tx, req.GroupId, req.Operator, wire.GroupRoleOwner); err != nil {
+			return err
+		}
+		if _, err := h.groupRole(tx, req.GroupId, req.Account); err != nil {
+			return err
+		}
+		var role byte = wire.GroupRoleMember
+		if req.Admin {
+			role = wire.GroupRoleAdmin
+		}
+		return h.setRole(tx, req.GroupId, req.Account, role)
+	})
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+}
+
+// GroupTransfer 把群主转让给另一个成员，原来的群主成为管理员
+func (h*ServiceHandle) GroupTransfer(c iris.Context) {
+	var req rpc.TransferGroupReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == req.Operator {
+		c.StopWithText(iris.StatusBadRequest, "already the owner")
+		return
+	}
+	err := h.BaseDb.Transaction(func(tx *gorm.DB) error {
+		if _, err := h.checkRole(tx, req.GroupId, req.Operator, wire.GroupRoleOwner); err != nil {
+			return err
+		}
+		if _, err := h.groupRole(tx, req.GroupId, req.Account); err != nil {
+			return err
+		}
+		if err := h.setRole(tx, req.GroupId, req.Account, wire.GroupRoleOwner); err != nil {
+			return err
+		}
+		if err := h.setRole(tx, req.GroupId, req.Operator, wire.GroupRoleAdmin); err != nil {
+			return err
+		}
+		return tx.Model(&database.Group{}).Where("`group`=?", req.GroupId).Update("owner", req.Account).Error
+	})
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+}
+
+func (h*ServiceHandle) GroupSetJoinMode(c iris.Context) {
+	var req rpc.SetGroupJoinModeReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.JoinMode < wire.GroupJoinOpen || req.JoinMode > wire.GroupJoinInviteOnly {
+		c.StopWithText(iris.StatusBadRequest, "invalid join mode")
+		return
+	}
+	_, err := h.checkRole(h.BaseDb, req.GroupId, req.Operator, wire.GroupRoleAdmin)
+	if err == nil {
+		err = h.BaseDb.Model(&database.Group{}).Where("`group`=?", req.GroupId).Update("join_mode", req.JoinMode).Error
+	}
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+}
+
+// GroupDissolve 只有群主可以解散群，群的成员和入群申请一起删除，历史消息保留
+func (h*ServiceHandle) GroupDissolve(c iris.Context) {
+	var req rpc.DissolveGroupReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	err := h.BaseDb.Transaction(func(tx *gorm.DB) error {
+		if _, err := h.checkRole(tx, req.GroupId, req.Operator, wire.GroupRoleOwner); err != nil {
+			return err
+		}
+		if err := tx.Where("`group`=?", req.GroupId).Delete(&database.GroupMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("`group`=?", req.GroupId).Delete(&database.GroupJoinRequest{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("`group`=?", req.GroupId).Delete(&database.Group{}).Error
+	})
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+}
+
+// GroupMute 群主和管理员设置全群禁言，禁言期间只有群主和管理员可以发言
+func (h*ServiceHandle) GroupMute(c iris.Context) {
+	var req rpc.MuteGroupReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	_, err := h.checkRole(h.BaseDb, req.GroupId, req.Operator, wire.GroupRoleAdmin)
+	if err == nil {
+		err = h.BaseDb.Model(&database.Group{}).Where("`group`=?", req.GroupId).Update("muted", req.Muted).Error
+	}
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+}
+
+// GroupMuteMember 禁言一个成员duration秒，和移除成员一样只能操作角色比自己低的成员
+func (h*ServiceHandle) GroupMuteMember(c iris.Context) {
+	var req rpc.MuteMemberReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Duration < 0 {
+		c.StopWithText(iris.StatusBadRequest, "invalid duration")
+		return
+	}
+	var mutedUntil int64
+	if req.Duration > 0 {
+		mutedUntil = time.Now().Unix() + req.Duration
+	}
+	err := h.BaseDb.Transaction(func(tx *gorm.DB) error {
+		role, err := h.checkRole(tx, req.GroupId, req.Operator, wire.GroupRoleAdmin)
+		if err != nil {
+			return err
+		}
+		target, err := h.groupRole(tx, req.GroupId, req.Account)
+		if err != nil {
+			return err
+		}
+		if target >= role {
+			return ErrGroupPermission
+		}
+		return tx.Model(&database.GroupMember{}).Where("`group`=? and account=?", req.GroupId, req.Account).Update("muted_until", mutedUntil).Error
+	})
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+	_, _ = c.Negotiate(&rpc.MuteMemberResp{MutedUntil: mutedUntil})
+}
+
+func (h*ServiceHandle) GroupSetAnnouncement(c iris.Context) {
+	var req rpc.SetAnnouncementReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if utf8.RuneCountInString(req.Announcement) > wire.GroupAnnouncementLength {
+		c.StopWithText(iris.StatusBadRequest, "announcement is too long")
+		return
+	}
+	now := time.Now().Unix()
+	_, err := h.checkRole(h.BaseDb, req.GroupId, req.Operator, wire.GroupRoleAdmin)
+	if err == nil {
+		err = h.BaseDb.Model(&database.Group{}).Where("`group`=?", req.GroupId).Updates(map[string]interface{}{
+			"announcement":    req.Announcement,
+			"announcement_by": req.Operator,
+			"announcement_at": now,
+		}).Error
 	}
-	err := h.BaseDb.Delete(&g).Error
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+	_, _ = c.Negotiate(&rpc.SetAnnouncementResp{UpdatedAt: now})
+}
+
+// GroupJoinRequests 等待审批的入群申请，只有群主和管理员可以查看
+func (h*ServiceHandle) GroupJoinRequests(c iris.Context) {
+	var req rpc.GroupJoinRequestsReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if _, err := h.checkRole(h.BaseDb, req.GroupId, req.Operator, wire.GroupRoleAdmin); err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+	var list []database.GroupJoinRequest
+	err := h.BaseDb.Where("`group`=? and status=?", req.GroupId, database.GroupJoinPending).Order("created_at asc").Limit(100).Find(&list).Error
 	if err != nil {
 		c.StopWithError(iris.StatusInternalServerError, err)
 		return
 	}
+	var resp = &rpc.GroupJoinRequestsResp{List: make([]*rpc.GroupJoinRequest, len(list))}
+	for i, jr := range list {
+		resp.List[i] = &rpc.GroupJoinRequest{
+			Id: jr.ID,
+			Account: jr.Account,
+			Inviter: jr.Inviter,
+			CreatedAt: jr.CreatedAt.Unix(),
+		}
+	}
+	_, _ = c.Negotiate(resp)
+}
+
+func (h*ServiceHandle) GroupHandleJoin(c iris.Context) {
+	var req rpc.HandleGroupJoinReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	var jr database.GroupJoinRequest
+	err := h.BaseDb.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id=? and status=?", req.RequestId, database.GroupJoinPending).First(&jr).Error
+		if err != nil {
+			return err
+		}
+		if _, err = h.checkRole(tx, jr.Group, req.Operator, wire.GroupRoleAdmin); err != nil {
+			return err
+		}
+		status := database.GroupJoinRejected
+		if req.Approve {
+			status = database.GroupJoinApproved
+		}
+		if err = tx.Model(&jr).Update("status", status).Error; err != nil {
+			return err
+		}
+		if !req.Approve {
+			return nil
+		}
+		return h.addMember(tx, jr.Group, jr.Account)
+	})
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
+	_, _ = c.Negotiate(&rpc.HandleGroupJoinResp{
+		GroupId: jr.Group,
+		Account: jr.Account,
+	})
+}
+
+func (h*ServiceHandle) findGroup(groupId string) (*database.Group, error) {
+	var group database.Group
+	err := h.BaseDb.Where("`group`=?", groupId).First(&group).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrGroupNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// groupRole 成员在群中的角色，不是成员时返回gorm.ErrRecordNotFound
+func (h*ServiceHandle) groupRole(tx *gorm.DB, groupId, account string) (byte, error) {
+	var gm database.GroupMember
+	err := tx.Select("role").Where("`group`=? and account=?", groupId, account).First(&gm).Error
+	if err != nil {
+		return 0, err
+	}
+	return gm.Role, nil
+}
+
+// checkRole 检查操作人的角色不低于min
+func (h*ServiceHandle) checkRole(tx *gorm.DB, groupId, operator string, min byte) (byte, error) {
+	role, err := h.groupRole(tx, groupId, operator)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if _, err = h.findGroup(groupId); err != nil {
+			return 0, err
+		}
+		return 0, ErrNotGroupMember
+	}
+	if err != nil {
+		return 0, err
+	}
+	if role < min {
+		return role, ErrGroupPermission
+	}
+	return role, nil
+}
+
+func (h*ServiceHandle) setRole(tx *gorm.DB, groupId, account string, role byte) error {
+	return tx.Model(&database.GroupMember{}).Where("`group`=? and account=?", groupId, account).Update("role", role).Error
+}
+
+// addMember 已经是成员时不做任何修改
+func (h*ServiceHandle) addMember(tx *gorm.DB, groupId, account string) error {
+	gm := &database.GroupMember{
+		Model: database.Model{ID: h.Idgen.Next().Int64()},
+		Account: account,
+		Group: groupId,
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(gm).Error; err != nil {
+		return err
+	}
+	if group, err := h.findGroup(groupId); err == nil && group.ReadDiffusion {
+		h.joinConversations(groupId, []database.GroupMember{*gm})
+	}
+	return nil
+}
+
+func (h*ServiceHandle) removeMember(tx *gorm.DB, groupId, account string) error {
+	if err := tx.Where("`group`=? and account=?", groupId, account).Delete(&database.GroupMember{}).Error; err != nil {
+		return err
+	}
+	if group, err := h.findGroup(groupId); err == nil && group.ReadDiffusion {
+		h.leaveConversation(groupId, account)
+	}
+	return nil
+}
+
+// stopWithGroupError 把群操作的错误转换为对应的状态码
+func stopWithGroupError(c iris.Context, err error) {
+	switch {
+	case err == ErrGroupPermission || err == ErrNotGroupMember:
+		c.StopWithText(iris.StatusForbidden, err.Error())
+	case err == ErrGroupNotFound || errors.Is(err, gorm.ErrRecordNotFound):
+		c.StopWithText(iris.StatusNotFound, err.Error())
+	case err == ErrOwnerCannotQuit:
+		c.StopWithText(iris.StatusBadRequest, err.Error())
+	default:
+		c.StopWithError(iris.StatusInternalServerError, err)
+	}
 }
 
 func (h*ServiceHandle) GroupMembers(c iris.Context) {
@@ -105,8 +537,13 @@ func (h*ServiceHandle) GroupMembers(c iris.Context) {
 		c.StopWithError(iris.StatusBadRequest, errors.New("group is nil"))
 		return
 	}
+	g, err := h.findGroup(group)
+	if err != nil {
+		stopWithGroupError(c, err)
+		return
+	}
 	var members []database.GroupMember
-	err := h.BaseDb.Order("Updated_At asc").Find(&members, database.GroupMember{Group: group}).Error
+	err = h.BaseDb.Order("created_at asc").Find(&members, database.GroupMember{Group: group}).Error
 	if err != nil {
 		c.StopWithError(iris.StatusInternalServerError, err)
 		return
@@ -117,9 +554,11 @@ func (h*ServiceHandle) GroupMembers(c iris.Context) {
 			Account:  m.Account,
 			Alias:    m.Alias,
 			JoinTime: m.CreatedAt.Unix(),
+			Role:     int32(m.Role),
+			MutedUntil: m.MutedUntil,
 		}
 	}
-	_,_ = c.Negotiate(&rpc.GroupMembersResp{Users: users})
+	_,_ = c.Negotiate(&rpc.GroupMembersResp{Users: users, Muted: g.Muted})
 }
 
 func (h*ServiceHandle) GroupGet (c iris.Context) {
@@ -146,5 +585,10 @@ func (h*ServiceHandle) GroupGet (c iris.Context) {
 		Owner: group.Owner,
 		CreatedAt: group.CreatedAt.Unix(),
 		Introduction: group.Introduction,
+		JoinMode: int32(group.JoinMode),
+		Muted: group.Muted,
+		Announcement: group.Announcement,
+		AnnouncementBy: group.AnnouncementBy,
+		AnnouncementAt: group.AnnouncementAt,
 	})
 }
\ No newline at end of file
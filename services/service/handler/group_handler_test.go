@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"kingim/wire"
+	"kingim/wire/rpc"
+)
+
+func TestGroupSetAnnouncement_Permission(t *testing.T) {
+	t.Run("member", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		mock.ExpectQuery("SELECT `role` FROM `t_group_member` WHERE `group`=\\? and account=\\?").WithArgs("g1", "test2").
+			WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(wire.GroupRoleMember))
+
+		code := serve(t, h.GroupSetAnnouncement, &rpc.SetAnnouncementReq{GroupId: "g1", Operator: "test2", Announcement: "hi"}, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+	t.Run("not member", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		mock.ExpectQuery("FROM `t_group_member`").WillReturnRows(sqlmock.NewRows([]string{"role"}))
+		mock.ExpectQuery("FROM `t_group` WHERE `group`=\\?").WithArgs("g1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "group"}).AddRow(1, "g1"))
+
+		code := serve(t, h.GroupSetAnnouncement, &rpc.SetAnnouncementReq{GroupId: "g1", Operator: "test4", Announcement: "hi"}, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+	t.Run("group not found", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		mock.ExpectQuery("FROM `t_group_member`").WillReturnRows(sqlmock.NewRows([]string{"role"}))
+		mock.ExpectQuery("FROM `t_group`").WillReturnRows(sqlmock.NewRows([]string{"id", "group"}))
+
+		code := serve(t, h.GroupSetAnnouncement, &rpc.SetAnnouncementReq{GroupId: "g2", Operator: "test1", Announcement: "hi"}, nil)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+	t.Run("admin", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		mock.ExpectQuery("FROM `t_group_member`").
+			WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(wire.GroupRoleAdmin))
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `t_group` SET").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		var resp rpc.SetAnnouncementResp
+		code := serve(t, h.GroupSetAnnouncement, &rpc.SetAnnouncementReq{GroupId: "g1", Operator: "test1", Announcement: "hi"}, &resp)
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotZero(t, resp.UpdatedAt)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGroupKick_Permission(t *testing.T) {
+	// 管理员不能踢出其它管理员
+	h, mock := newTestHandle(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM `t_group_member`").WithArgs("g1", "test1").
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(wire.GroupRoleAdmin))
+	mock.ExpectQuery("FROM `t_group_member`").WithArgs("g1", "test2").
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(wire.GroupRoleAdmin))
+	mock.ExpectRollback()
+
+	code := serve(t, h.GroupKick, &rpc.KickGroupMemberReq{GroupId: "g1", Operator: "test1", Account: "test2"}, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kataras/iris/v12"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+	"kingim/services/service/database"
+	"kingim/services/service/search"
+)
+
+// fakeSearcher 记录索引的变化，Search返回固定的结果
+type fakeSearcher struct {
+	hits    []search.Hit
+	query   *search.Query
+	removed []int64
+}
+
+func (s *fakeSearcher) Index(*search.Document) error {
+	return nil
+}
+
+func (s *fakeSearcher) Remove(messageId int64) error {
+	s.removed = append(s.removed, messageId)
+	return nil
+}
+
+func (s *fakeSearcher) Search(q *search.Query) ([]search.Hit, error) {
+	s.query = q
+	return s.hits, nil
+}
+
+// newTestHandle BaseDb和MessageDb使用同一个sqlmock，表名和线上的配置相同
+func newTestHandle(t *testing.T) (*ServiceHandle, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		NamingStrategy: schema.NamingStrategy{
+			TablePrefix:   "t_",
+			SingularTable: true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	idgen, err := database.NewIDGenerator(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ServiceHandle{
+		BaseDb:       db,
+		MessageDb:    db,
+		Idgen:        idgen,
+		Searcher:     &fakeSearcher{},
+		RecallWindow: time.Minute * 2,
+	}, mock
+}
+
+// serve 通过iris调用handler，请求和响应都使用JSON，resp不为空时解析响应
+func serve(t *testing.T, handler iris.Handler, req, resp proto.Message) int {
+	app := iris.New()
+	app.UseRouter(func(ctx iris.Context) {
+		ctx.Negotiation().JSON()
+		ctx.Negotiation().Accept.JSON()
+		ctx.Next()
+	})
+	app.Post("/test", handler)
+	assert.Nil(t, app.Build())
+
+	body, err := json.Marshal(req)
+	assert.Nil(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(string(body)))
+	r.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, r)
+	if resp != nil && rec.Code == http.StatusOK {
+		assert.Nil(t, protojson.Unmarshal(rec.Body.Bytes(), resp))
+	}
+	return rec.Code
+}
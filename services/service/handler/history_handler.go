@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/kataras/iris/v12"
+	"gorm.io/gorm"
+	"kingim/services/service/database"
+	"kingim/wire"
+	"kingim/wire/rpc"
+)
+
+func (h *ServiceHandle) GetHistory(c iris.Context) {
+	var req rpc.GetHistoryReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == "" || req.Dest == "" {
+		c.StopWithText(iris.StatusBadRequest, "account and dest are required")
+		return
+	}
+	resp, err := h.getHistory(&req)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.StopWithText(iris.StatusNotFound, "message not found in this conversation")
+		return
+	}
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	_, _ = c.Negotiate(resp)
+}
+
+// getHistory 按(send_time, message_id)翻页，游标消息本身不在结果中
+func (h *ServiceHandle) getHistory(req *rpc.GetHistoryReq) (*rpc.GetHistoryResp, error) {
+	limit := int(req.Limit)
+	if limit <= 0 || limit > wire.MessageMaxCountPerPage {
+		limit = wire.MessageMaxCountPerPage
+	}
+	conversation := func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("account_a=?", req.Account)
+		if req.Group {
+			return tx.Where("`group`=?", req.Dest)
+		}
+		return tx.Where("account_b=? and `group`=''", req.Dest)
+	}
+	var cursor database.MessageIndex
+	if req.MessageId > 0 {
+		err := h.MessageDb.Select("send_time").Scopes(conversation).Where("message_id=?", req.MessageId).First(&cursor).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) && req.Group {
+			// 读扩散的群中消息只在时间线中
+			var entry *database.GroupTimeline
+			if entry, err = h.timelineMessage(req.Dest, req.MessageId); err == nil {
+				cursor.SendTime = entry.SendTime
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 索引和群的时间线中的列名相同，使用相同的游标和排序
+	page := func(tx *gorm.DB) *gorm.DB {
+		if req.MessageId > 0 {
+			if req.Forward {
+				tx = tx.Where("(send_time>? or (send_time=? and message_id>?))", cursor.SendTime, cursor.SendTime, req.MessageId)
+			} else {
+				tx = tx.Where("(send_time<? or (send_time=? and message_id<?))", cursor.SendTime, cursor.SendTime, req.MessageId)
+			}
+		}
+		if req.Forward {
+			tx = tx.Order("send_time asc, message_id asc")
+		} else {
+			tx = tx.Order("send_time desc, message_id desc")
+		}
+		// 多取一条用于判断是否还有更多
+		return tx.Limit(limit + 1)
+	}
+	var indexes []database.MessageIndex
+	if err := h.MessageDb.Model(&database.MessageIndex{}).Scopes(conversation, page).Find(&indexes).Error; err != nil {
+		return nil, err
+	}
+	if req.Group {
+		entries, err := h.historyTimeline(req.Account, req.Dest, page)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) > 0 {
+			indexes = append(indexes, timelineToIndexes(req.Account, entries)...)
+			sort.Slice(indexes, func(i, j int) bool {
+				a, b := indexes[i], indexes[j]
+				if a.SendTime != b.SendTime {
+					return (a.SendTime < b.SendTime) == req.Forward
+				}
+				return (a.MessageID < b.MessageID) == req.Forward
+			})
+		}
+	}
+	resp := &rpc.GetHistoryResp{}
+	if len(indexes) > limit {
+		resp.HasMore = true
+		indexes = indexes[:limit]
+	}
+	if len(indexes) == 0 {
+		return resp, nil
+	}
+	ids := make([]int64, len(indexes))
+	for i, index := range indexes {
+		ids[i] = index.MessageID
+	}
+	var contents []database.MessageCount
+	if err := h.MessageDb.Where(ids).Find(&contents).Error; err != nil {
+		return nil, err
+	}
+	contentMap := make(map[int64]*database.MessageCount, len(contents))
+	for i := range contents {
+		contentMap[contents[i].ID] = &contents[i]
+	}
+	resp.List = make([]*rpc.HistoryMessage, 0, len(indexes))
+	for _, index := range indexes {
+		content, ok := contentMap[index.MessageID]
+		if !ok {
+			continue
+		}
+		msg := &rpc.HistoryMessage{
+			MessageId: index.MessageID,
+			Sender:    index.AccountB,
+			SendTime:  index.SendTime,
+			Type:      int32(content.Type),
+			Body:      content.Body,
+			Extra:     content.Extra,
+			Recalled:  content.Recalled,
+			EditedAt:  content.EditedAt,
+			Revision:  content.Revision,
+		}
+		// 单聊中direction为1表示自己是发送方，群聊中AccountB总是发送方
+		if index.Direction == 1 {
+			msg.Sender = req.Account
+		}
+		if content.Recalled {
+			msg.Body = ""
+			msg.Extra = ""
+		}
+		resp.List = append(resp.List, msg)
+	}
+	return resp, nil
+}
+
+// historyTimeline 群的时间线不区分成员，只有当前的成员可以读取加入之后的消息
+func (h *ServiceHandle) historyTimeline(account, groupId string, page func(*gorm.DB) *gorm.DB) ([]database.GroupTimeline, error) {
+	joined, err := h.joinedAt(groupId, account)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []database.GroupTimeline
+	if err = h.MessageDb.Where("`group`=? and send_time>?", groupId, joined).Scopes(page).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
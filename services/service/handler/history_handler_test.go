@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kingim/wire/rpc"
+)
+
+var (
+	indexColumns   = []string{"id", "account_a", "account_b", "direction", "message_id", "group", "send_time"}
+	contentColumns = []string{"id", "type", "body", "extra", "send_time", "recalled", "edited_at", "revision"}
+)
+
+func TestGetHistory_Cursor(t *testing.T) {
+	h, mock := newTestHandle(t)
+	mock.ExpectQuery("SELECT `send_time` FROM `t_message_index` WHERE message_id=\\? AND account_a=\\? AND \\(account_b=\\? and `group`=''\\)").
+		WithArgs(50, "test1", "test2").
+		WillReturnRows(sqlmock.NewRows([]string{"send_time"}).AddRow(300))
+	// 向前翻页，发送时间相同时按消息ID排序，多取一条判断是否还有更多
+	mock.ExpectQuery("\\(send_time<\\? or \\(send_time=\\? and message_id<\\?\\)\\)\\) ORDER BY send_time desc, message_id desc LIMIT 3").
+		WithArgs("test1", "test2", 300, 300, 50).
+		WillReturnRows(sqlmock.NewRows(indexColumns).
+			AddRow(1, "test1", "test2", 1, 40, "", 300).
+			AddRow(2, "test1", "test2", 0, 30, "", 200).
+			AddRow(3, "test1", "test2", 0, 20, "", 100))
+	mock.ExpectQuery("FROM `t_message_count` WHERE `t_message_count`.`id` IN \\(\\?,\\?\\)").WithArgs(40, 30).
+		WillReturnRows(sqlmock.NewRows(contentColumns).
+			AddRow(40, 1, "hello", "", 300, false, 0, 0).
+			AddRow(30, 1, "secret", "x", 200, true, 0, 0))
+
+	var resp rpc.GetHistoryResp
+	code := serve(t, h.GetHistory, &rpc.GetHistoryReq{Account: "test1", Dest: "test2", MessageId: 50, Limit: 2}, &resp)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.HasMore)
+	require.Equal(t, 2, len(resp.List))
+	assert.Equal(t, int64(40), resp.List[0].MessageId)
+	assert.Equal(t, "test1", resp.List[0].Sender)
+	assert.Equal(t, "hello", resp.List[0].Body)
+	// 撤回的消息只返回墓碑
+	assert.Equal(t, int64(30), resp.List[1].MessageId)
+	assert.Equal(t, "test2", resp.List[1].Sender)
+	assert.True(t, resp.List[1].Recalled)
+	assert.Empty(t, resp.List[1].Body)
+	assert.Empty(t, resp.List[1].Extra)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetHistory_CursorNotFound(t *testing.T) {
+	h, mock := newTestHandle(t)
+	mock.ExpectQuery("FROM `t_message_index`").WillReturnRows(sqlmock.NewRows([]string{"send_time"}))
+
+	code := serve(t, h.GetHistory, &rpc.GetHistoryReq{Account: "test1", Dest: "test2", MessageId: 50}, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetHistory_Timeline(t *testing.T) {
+	t.Run("member", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		joined := time.Unix(0, 150)
+		mock.ExpectQuery("FROM `t_message_index` WHERE account_a=\\? AND `group`=\\? ORDER BY send_time asc").
+			WithArgs("test1", "g1").
+			WillReturnRows(sqlmock.NewRows(indexColumns).AddRow(1, "test1", "test2", 0, 10, "g1", 100))
+		// 只能读取加入之后的时间线
+		mock.ExpectQuery("FROM `t_group_member` WHERE `group`=\\? and account=\\?").WithArgs("g1", "test1").
+			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(joined))
+		mock.ExpectQuery("FROM `t_group_timeline` WHERE `group`=\\? and send_time>\\? ORDER BY send_time asc").
+			WithArgs("g1", joined.UnixNano()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "group", "sender", "message_id", "send_time"}).
+				AddRow(5, "g1", "test3", 30, 300).
+				AddRow(6, "g1", "test1", 20, 200))
+		mock.ExpectQuery("FROM `t_message_count`").
+			WillReturnRows(sqlmock.NewRows(contentColumns).
+				AddRow(10, 1, "a", "", 100, false, 0, 0).
+				AddRow(20, 1, "b", "", 200, false, 0, 0).
+				AddRow(30, 1, "c", "", 300, false, 0, 0))
+
+		var resp rpc.GetHistoryResp
+		code := serve(t, h.GetHistory, &rpc.GetHistoryReq{Account: "test1", Dest: "g1", Group: true, Forward: true}, &resp)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 3, len(resp.List))
+		assert.Equal(t, int64(10), resp.List[0].MessageId)
+		assert.Equal(t, int64(20), resp.List[1].MessageId)
+		assert.Equal(t, "test1", resp.List[1].Sender)
+		assert.Equal(t, int64(30), resp.List[2].MessageId)
+		assert.Equal(t, "test3", resp.List[2].Sender)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+	t.Run("not member", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		mock.ExpectQuery("FROM `t_message_index`").WillReturnRows(sqlmock.NewRows(indexColumns))
+		mock.ExpectQuery("FROM `t_group_member`").WillReturnRows(sqlmock.NewRows([]string{"created_at"}))
+
+		var resp rpc.GetHistoryResp
+		code := serve(t, h.GetHistory, &rpc.GetHistoryReq{Account: "test4", Dest: "g1", Group: true}, &resp)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, resp.List)
+		assert.False(t, resp.HasMore)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,14 +1,23 @@
 package handler
 
 import (
+	"errors"
 	"github.com/go-redis/redis/v7"
 	"github.com/kataras/iris/v12"
 	"kingim/services/service/database"
+	"kingim/services/service/search"
 	"kingim/wire"
 	"kingim/wire/rpc"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNotSender     = errors.New("only the sender can change the message")
+	ErrRecallExpired = errors.New("the message can no longer be recalled")
+	ErrNotEditable   = errors.New("only text messages that are not recalled can be edited")
 )
 
 type ServiceHandle struct {
@@ -16,6 +25,11 @@ type ServiceHandle struct {
 	MessageDb *gorm.DB
 	Cache     *redis.Client
 	Idgen     *database.IDGenerator
+	Searcher  search.Searcher
+	// RecallWindow 消息发送之后可以撤回的时间
+	RecallWindow time.Duration
+	// LargeGroupThreshold 群成员数超过这个值之后改为读扩散，0表示总是写扩散
+	LargeGroupThreshold int
 }
 
 func (h*ServiceHandle) InsertUserMessage(c iris.Context) {
@@ -60,6 +74,10 @@ func (h*ServiceHandle) insertUserMessage(req *rpc.InsertMessageReq) (int64, erro
 		SendTime: req.SendTime,
 		Direction: 1,
 	}
+	convs := []database.Conversation{
+		newConversation(h.Idgen.Next().Int64(), req.Dest, req.Sender, false, messageId, req),
+		newConversation(h.Idgen.Next().Int64(), req.Sender, req.Dest, false, messageId, req),
+	}
 	err := h.MessageDb.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&messageCount).Error; err!= nil {  // 保存信息体
 			return err
@@ -67,11 +85,19 @@ func (h*ServiceHandle) insertUserMessage(req *rpc.InsertMessageReq) (int64, erro
 		if err := tx.Create(&idxs).Error; err != nil {
 			return err
 		}
-		return nil
+		return upsertConversations(tx, convs)
 	})
 	if err != nil {
 		return 0,err
 	}
+	h.index(&search.Document{
+		MessageID: messageId,
+		Sender: req.Sender,
+		Dest: req.Dest,
+		Type: req.Message.Type,
+		Body: req.Message.Body,
+		SendTime: req.SendTime,
+	})
 	return messageId,nil
 }
 
@@ -82,6 +108,10 @@ func (h*ServiceHandle) InsertGroupMessage(c iris.Context) {
 		return
 	}
 	messageId, err := h.insertGroupMessage(&req)
+	if err == ErrGroupNotFound {
+		c.StopWithText(iris.StatusNotFound, err.Error())
+		return
+	}
 	if err != nil {
 		c.StopWithError(iris.StatusInternalServerError, err)
 		return
@@ -94,18 +124,67 @@ func (h*ServiceHandle) InsertGroupMessage(c iris.Context) {
 func (h*ServiceHandle) insertGroupMessage(req *rpc.InsertMessageReq) (int64, error) {
 	messageID := h.Idgen.Next().Int64()
 
+	group, err := h.findGroup(req.Dest)
+	if err != nil {
+		return 0, err
+	}
 	var members []database.GroupMember
-	// 获取成员列表  ，在群信息中 Dest中保存的是群id
-	err := h.BaseDb.Where(&database.GroupMember{Group: req.Dest}).Find(&members).Error
+	if !group.ReadDiffusion {
+		// 获取成员列表  ，在群信息中 Dest中保存的是群id
+		err = h.BaseDb.Where(&database.GroupMember{Group: req.Dest}).Find(&members).Error
+		if err != nil {
+			return 0,err
+		}
+		// 成员数超过阈值之后这个群一直使用读扩散
+		if h.LargeGroupThreshold > 0 && len(members) > h.LargeGroupThreshold {
+			if err = h.BaseDb.Model(group).Update("read_diffusion", true).Error; err != nil {
+				return 0, err
+			}
+			group.ReadDiffusion = true
+			h.joinConversations(req.Dest, members)
+		}
+	}
+	messageCount := database.MessageCount{
+		ID: messageID,
+		Type: byte(req.Message.Type),
+		Body: req.Message.Body,
+		Extra: req.Message.Extra,
+		SendTime: req.SendTime,
+	}
+	if group.ReadDiffusion {
+		err = h.MessageDb.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&messageCount).Error; err!= nil {
+				return err
+			}
+			return h.insertTimeline(tx, messageID, req)
+		})
+	} else {
+		err = h.writeGroupMessage(&messageCount, members, req)
+	}
 	if err != nil {
-		return 0,err
+		return 0, err
 	}
+	h.index(&search.Document{
+		MessageID: messageID,
+		Sender: req.Sender,
+		Dest: req.Dest,
+		Group: true,
+		Type: req.Message.Type,
+		Body: req.Message.Body,
+		SendTime: req.SendTime,
+	})
+	return messageID, nil
+}
+
+// writeGroupMessage 写扩散，每个成员一条索引
+func (h*ServiceHandle) writeGroupMessage(messageCount *database.MessageCount, members []database.GroupMember, req *rpc.InsertMessageReq) error {
 	// 群扩散
 	var idxs = make([]database.MessageIndex, len(members))
+	var convs = make([]database.Conversation, len(members))
 	for i, m := range members {
 		idxs[i] = database.MessageIndex{
 			ID: h.Idgen.Next().Int64(),
-			MessageID: messageID,
+			MessageID: messageCount.ID,
 			AccountA: m.Account,
 			AccountB: req.Sender,
 			Direction: 0,
@@ -115,27 +194,17 @@ func (h*ServiceHandle) insertGroupMessage(req *rpc.InsertMessageReq) (int64, err
 		if m.Account == req.Sender {
 			idxs[i].Direction = 1
 		}
+		convs[i] = newConversation(h.Idgen.Next().Int64(), m.Account, m.Group, true, messageCount.ID, req)
 	}
-	messageCount := database.MessageCount{
-		ID: h.Idgen.Next().Int64(),
-		Type: byte(req.Message.Type),
-		Body: req.Message.Body,
-		Extra: req.Message.Extra,
-		SendTime: req.SendTime,
-	}
-	err = h.MessageDb.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(&messageCount).Error; err!= nil {
+	return h.MessageDb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(messageCount).Error; err!= nil {
 			return err
 		}
 		if err := tx.Create(&idxs).Error; err != nil {
 			return err
 		}
-		return nil
+		return upsertConversations(tx, convs)
 	})
-	if err != nil {
-		return 0, err
-	}
-	return messageID, nil
 }
 
 func (h*ServiceHandle) MessageAck(c iris.Context) {
@@ -157,7 +226,88 @@ func setMessageAck(cache *redis.Client, account string, msgId int64) error {
 	}
 	// 保存信息id至读索引
 	key := database.KeyMessageAckIndex(account)
-	return cache.Set(key, msgId, wire.OfflineMessageExpiresIn).Err()
+	return cache.Set(key, msgId, wire.OfflineReadIndexExpiresIn).Err()
+}
+
+func (h*ServiceHandle) MessageRead(c iris.Context) {
+	var req rpc.ReadMessageReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == "" || req.Dest == "" || req.MessageId == 0 {
+		c.StopWithText(iris.StatusBadRequest, "account, dest and message_id are required")
+		return
+	}
+	updated, err := h.setReadCursor(&req)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.StopWithText(iris.StatusNotFound, "message not found in this conversation")
+		return
+	}
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	_,_ = c.Negotiate(&rpc.ReadMessageResp{Updated: updated})
+}
+
+// setReadCursor 更新会话的已读位置，已读位置只前进不后退
+func (h*ServiceHandle) setReadCursor(req *rpc.ReadMessageReq) (bool, error) {
+	// 消息必须在这个账号的这个会话中
+	var index database.MessageIndex
+	tx := h.MessageDb.Select("send_time").Where("account_a=? and message_id=?", req.Account, req.MessageId)
+	if req.Group {
+		tx = tx.Where("`group`=?", req.Dest)
+	} else {
+		tx = tx.Where("account_b=? and `group`=''", req.Dest)
+	}
+	err := tx.First(&index).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) && req.Group {
+		// 读扩散的群中消息只在时间线中，只有群成员可以标记加入之后的消息
+		index.SendTime, err = h.timelineSendTime(req.Dest, req.Account, req.MessageId)
+	}
+	if err != nil {
+		return false, err
+	}
+	cursor := database.ReadCursor{
+		ID: h.Idgen.Next().Int64(),
+		Account: req.Account,
+		Dest: req.Dest,
+		Group: req.Group,
+		MessageID: req.MessageId,
+		SendTime: index.SendTime,
+	}
+	// message_id 必须在 send_time 之前更新，此时send_time还是旧的值
+	result := h.MessageDb.Clauses(clause.OnConflict{
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "message_id"}, Value: gorm.Expr("IF(VALUES(send_time) > send_time, VALUES(message_id), message_id)")},
+			{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("IF(VALUES(send_time) > send_time, VALUES(updated_at), updated_at)")},
+			{Column: clause.Column{Name: "send_time"}, Value: gorm.Expr("GREATEST(send_time, VALUES(send_time))")},
+		},
+	}).Create(&cursor)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	// MySQL中插入时影响1行，更新时影响2行，没有变化时为0
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	return true, h.resetUnread(req, index.SendTime)
+}
+
+// unreadCounts 统计每个会话中晚于已读位置的消息数
+func (h*ServiceHandle) unreadCounts(account string, since int64) ([]*rpc.UnreadCount, error) {
+	var unreads []*rpc.UnreadCount
+	err := h.MessageDb.Raw("SELECT IF(mi.`group`='', mi.account_b, mi.`group`) AS dest, mi.`group`<>'' AS `group`, "+
+		"COUNT(*) AS count, MAX(IFNULL(rc.message_id, 0)) AS read_message_id "+
+		"FROM t_message_index mi LEFT JOIN t_read_cursor rc "+
+		"ON rc.account=mi.account_a AND rc.dest=IF(mi.`group`='', mi.account_b, mi.`group`) AND rc.`group`=(mi.`group`<>'') "+
+		"WHERE mi.account_a=? AND mi.direction=0 AND mi.send_time>? AND mi.send_time>IFNULL(rc.send_time, 0) "+
+		"GROUP BY dest, `group`", account, since).Scan(&unreads).Error
+	if err != nil {
+		return nil, err
+	}
+	return unreads, nil
 }
 
 func (h*ServiceHandle) GetOfflineMessageIndex(c iris.Context) {
@@ -180,14 +330,40 @@ func (h*ServiceHandle) GetOfflineMessageIndex(c iris.Context) {
 		c.StopWithError(iris.StatusInternalServerError, err)
 		return
 	}
+	// 读扩散的群从群的时间线中读取
+	groups, err := h.timelineGroups(req.Account)
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	timeline, err := h.timelineIndexes(req.Account, groups, start, wire.OfflineSyncIndexCount)
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	indexes = mergeIndexes(indexes, timeline, wire.OfflineSyncIndexCount)
 	// 将信息id保存 // 表示同步到了的信息
 	err = setMessageAck(h.Cache, req.Account, msgId)
 	if err != nil {
 		c.StopWithError(iris.StatusInternalServerError, err)
 		return
 	}
+	// 未读数统计所有未过期的消息，而不只是本次同步的消息
+	since := time.Now().AddDate(0,0,-1).UnixNano()
+	unreads, err := h.unreadCounts(req.Account, since)
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	timelineUnreads, err := h.timelineUnreads(req.Account, groups, since)
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	unreads = mergeUnreads(unreads, timelineUnreads)
 	_,_ = c.Negotiate(&rpc.GetOfflineMessageIndexResp{
 		List: indexes,
+		Unreads: unreads,
 	})
 }
 
@@ -235,5 +411,167 @@ func (h*ServiceHandle) GetOfflineMessageContent(c iris.Context) {
 		c.StopWithError(iris.StatusInternalServerError, err)
 		return
 	}
+	// 已撤回的消息只返回一个墓碑
+	for _, content := range contents {
+		if content.Recalled {
+			content.Body = ""
+			content.Extra = ""
+		}
+	}
 	_,_ =c.Negotiate(&rpc.GetOfflineMessageContentResp{List: contents})
 }
+
+func (h*ServiceHandle) MessageRecall(c iris.Context) {
+	var req rpc.RecallMessageReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == "" || req.MessageId == 0 {
+		c.StopWithText(iris.StatusBadRequest, "account and message_id are required")
+		return
+	}
+	resp, err := h.recallMessage(&req)
+	if err == ErrNotSender {
+		c.StopWithText(iris.StatusForbidden, err.Error())
+		return
+	}
+	if err == ErrRecallExpired {
+		c.StopWithText(iris.StatusGone, err.Error())
+		return
+	}
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	_,_ = c.Negotiate(resp)
+}
+
+// recallMessage 撤回消息，只有发送方可以在RecallWindow内撤回，重复撤回直接返回成功
+func (h*ServiceHandle) recallMessage(req *rpc.RecallMessageReq) (*rpc.RecallMessageResp, error) {
+	dest, group, err := h.findSent(req.Account, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+	// 消息ID由雪花算法生成，包含了生成的时间
+	sendAt := time.Unix(0, h.Idgen.Parse(req.MessageId).Time()*int64(time.Millisecond))
+	if time.Since(sendAt) > h.RecallWindow {
+		return nil, ErrRecallExpired
+	}
+	err = h.MessageDb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&database.MessageCount{ID: req.MessageId}).Update("recalled", true).Error; err != nil {
+			return err
+		}
+		// 会话列表中的预览也不能再显示撤回的内容
+		return tx.Model(&database.Conversation{}).Where("last_message_id=?", req.MessageId).Update("last_body", "").Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.unindex(req.MessageId)
+	return &rpc.RecallMessageResp{Dest: dest, Group: group}, nil
+}
+
+// findSent 查找account发送的消息所在的会话，不是发送方时返回ErrNotSender
+func (h*ServiceHandle) findSent(account string, messageId int64) (string, bool, error) {
+	// 发送方的索引中direction为1
+	var index database.MessageIndex
+	err := h.MessageDb.Select("account_b", "group").Where("account_a=? and message_id=? and direction=?", account, messageId, 1).First(&index).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 读扩散的群
+		var entry database.GroupTimeline
+		err = h.MessageDb.Select("group").Where("message_id=? and sender=?", messageId, account).First(&entry).Error
+		if err == nil {
+			return entry.Group, true, nil
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", false, ErrNotSender
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if index.Group != "" {
+		return index.Group, true, nil
+	}
+	return index.AccountB, false, nil
+}
+
+func (h*ServiceHandle) MessageEdit(c iris.Context) {
+	var req rpc.EditMessageReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == "" || req.MessageId == 0 || req.Body == "" {
+		c.StopWithText(iris.StatusBadRequest, "account, message_id and body are required")
+		return
+	}
+	resp, err := h.editMessage(&req)
+	if err == ErrNotSender {
+		c.StopWithText(iris.StatusForbidden, err.Error())
+		return
+	}
+	if err == ErrNotEditable {
+		c.StopWithText(iris.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	_,_ = c.Negotiate(resp)
+}
+
+// editMessage 修改文本消息，修改前的内容保存到message_revision中
+func (h*ServiceHandle) editMessage(req *rpc.EditMessageReq) (*rpc.EditMessageResp, error) {
+	dest, group, err := h.findSent(req.Account, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &rpc.EditMessageResp{Dest: dest, Group: group, EditedAt: time.Now().UnixNano()}
+	var content database.MessageCount
+	err = h.MessageDb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&content, req.MessageId).Error; err != nil {
+			return err
+		}
+		if content.Type != wire.MessageTypeText || content.Recalled {
+			return ErrNotEditable
+		}
+		revision := database.MessageRevision{
+			ID: h.Idgen.Next().Int64(),
+			MessageID: content.ID,
+			Revision: content.Revision,
+			Body: content.Body,
+			Extra: content.Extra,
+			EditedAt: resp.EditedAt,
+		}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+		resp.Revision = content.Revision + 1
+		err := tx.Model(&content).Updates(map[string]interface{}{
+			"body": req.Body,
+			"extra": req.Extra,
+			"edited_at": resp.EditedAt,
+			"revision": resp.Revision,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&database.Conversation{}).Where("last_message_id=?", req.MessageId).Update("last_body", preview(req.Body)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.index(&search.Document{
+		MessageID: req.MessageId,
+		Sender: req.Account,
+		Dest: dest,
+		Group: group,
+		Type: int32(content.Type),
+		Body: req.Body,
+		SendTime: content.SendTime,
+	})
+	return resp, nil
+}
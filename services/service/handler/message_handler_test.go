@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bwmarrin/snowflake"
+	"github.com/stretchr/testify/assert"
+	"kingim/wire/rpc"
+)
+
+// sentAt 构造在t时刻生成的消息ID
+func sentAt(t time.Time) int64 {
+	return (t.UnixNano()/int64(time.Millisecond) - snowflake.Epoch) << (snowflake.NodeBits + snowflake.StepBits)
+}
+
+func TestMessageRecall(t *testing.T) {
+	t.Run("not sender", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		mock.ExpectQuery("FROM `t_message_index` WHERE account_a=\\? and message_id=\\? and direction=\\?").
+			WithArgs("test2", 100, 1).WillReturnRows(sqlmock.NewRows([]string{"account_b", "group"}))
+		mock.ExpectQuery("FROM `t_group_timeline` WHERE message_id=\\? and sender=\\?").
+			WithArgs(100, "test2").WillReturnRows(sqlmock.NewRows([]string{"group"}))
+
+		code := serve(t, h.MessageRecall, &rpc.RecallMessageReq{Account: "test2", MessageId: 100}, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+	t.Run("expired", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		messageId := sentAt(time.Now().Add(-h.RecallWindow - time.Second))
+		mock.ExpectQuery("FROM `t_message_index`").
+			WillReturnRows(sqlmock.NewRows([]string{"account_b", "group"}).AddRow("test2", ""))
+
+		code := serve(t, h.MessageRecall, &rpc.RecallMessageReq{Account: "test1", MessageId: messageId}, nil)
+		assert.Equal(t, http.StatusGone, code)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+	t.Run("in window", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		messageId := sentAt(time.Now().Add(-h.RecallWindow + time.Second*5))
+		mock.ExpectQuery("FROM `t_message_index`").
+			WillReturnRows(sqlmock.NewRows([]string{"account_b", "group"}).AddRow("test2", ""))
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `t_message_count` SET `recalled`=\\?").
+			WithArgs(true, messageId).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE `t_conversation` SET `last_body`=\\?").
+			WithArgs("", sqlmock.AnyArg(), messageId).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		var resp rpc.RecallMessageResp
+		code := serve(t, h.MessageRecall, &rpc.RecallMessageReq{Account: "test1", MessageId: messageId}, &resp)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "test2", resp.Dest)
+		assert.False(t, resp.Group)
+		assert.Equal(t, []int64{messageId}, h.Searcher.(*fakeSearcher).removed)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+	t.Run("group timeline", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		mock.ExpectQuery("FROM `t_message_index`").
+			WillReturnRows(sqlmock.NewRows([]string{"account_b", "group"}))
+		mock.ExpectQuery("FROM `t_group_timeline`").
+			WillReturnRows(sqlmock.NewRows([]string{"group"}).AddRow("g1"))
+
+		// 读扩散的群中发送方也受撤回时间的限制
+		code := serve(t, h.MessageRecall, &rpc.RecallMessageReq{Account: "test1", MessageId: sentAt(time.Now().Add(-time.Hour))}, nil)
+		assert.Equal(t, http.StatusGone, code)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMessageEdit_NotSender(t *testing.T) {
+	h, mock := newTestHandle(t)
+	mock.ExpectQuery("FROM `t_message_index`").
+		WillReturnRows(sqlmock.NewRows([]string{"account_b", "group"}))
+	mock.ExpectQuery("FROM `t_group_timeline`").
+		WillReturnRows(sqlmock.NewRows([]string{"group"}))
+
+	code := serve(t, h.MessageEdit, &rpc.EditMessageReq{Account: "test2", MessageId: 100, Body: "hi"}, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRead_Timeline(t *testing.T) {
+	t.Run("not member", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		mock.ExpectQuery("FROM `t_message_index`").WillReturnRows(sqlmock.NewRows([]string{"send_time"}))
+		mock.ExpectQuery("FROM `t_group_member` WHERE `group`=\\? and account=\\?").WithArgs("g1", "test4").
+			WillReturnRows(sqlmock.NewRows([]string{"created_at"}))
+
+		code := serve(t, h.MessageRead, &rpc.ReadMessageReq{Account: "test4", Dest: "g1", Group: true, MessageId: 10}, nil)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+	t.Run("before joined", func(t *testing.T) {
+		h, mock := newTestHandle(t)
+		mock.ExpectQuery("FROM `t_message_index`").WillReturnRows(sqlmock.NewRows([]string{"send_time"}))
+		mock.ExpectQuery("FROM `t_group_member`").WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Unix(0, 150)))
+		// 加入之前发送的消息不能标记为已读
+		mock.ExpectQuery("FROM `t_group_timeline` WHERE `group`=\\? and message_id=\\?").WithArgs("g1", 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "group", "sender", "message_id", "send_time"}).AddRow(5, "g1", "test3", 10, 100))
+
+		code := serve(t, h.MessageRead, &rpc.ReadMessageReq{Account: "test1", Dest: "g1", Group: true, MessageId: 10}, nil)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"github.com/kataras/iris/v12"
+	"kingim/logger"
+	"kingim/services/service/database"
+	"kingim/services/service/search"
+	"kingim/wire"
+	"kingim/wire/rpc"
+)
+
+func (h *ServiceHandle) MessageSearch(c iris.Context) {
+	var req rpc.SearchMessageReq
+	if err := c.ReadBody(&req); err != nil {
+		c.StopWithError(iris.StatusBadRequest, err)
+		return
+	}
+	if req.Account == "" || req.Keyword == "" {
+		c.StopWithText(iris.StatusBadRequest, "account and keyword are required")
+		return
+	}
+	if req.Peer != "" && req.Group != "" {
+		c.StopWithText(iris.StatusBadRequest, "peer and group can not be used together")
+		return
+	}
+	resp, err := h.searchMessage(&req)
+	if err == search.ErrEmptyKeyword {
+		c.StopWithText(iris.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		c.StopWithError(iris.StatusInternalServerError, err)
+		return
+	}
+	_, _ = c.Negotiate(resp)
+}
+
+func (h *ServiceHandle) searchMessage(req *rpc.SearchMessageReq) (*rpc.SearchMessageResp, error) {
+	limit := int(req.Limit)
+	if limit <= 0 || limit > wire.MessageMaxCountPerPage {
+		limit = wire.MessageMaxCountPerPage
+	}
+	groups, err := h.timelineGroups(req.Account)
+	if err != nil {
+		return nil, err
+	}
+	// 多取一条用于判断是否还有更多
+	hits, err := h.Searcher.Search(&search.Query{
+		Account:   req.Account,
+		Keyword:   req.Keyword,
+		Peer:      req.Peer,
+		Group:     req.Group,
+		Groups:    groupIds(groups),
+		Types:     req.Types,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Before:    req.Before,
+		Limit:     limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &rpc.SearchMessageResp{}
+	if len(hits) > limit {
+		hits = hits[:limit]
+		resp.NextCursor = hits[limit-1].SendTime
+	}
+	if len(hits) == 0 {
+		return resp, nil
+	}
+	ids := make([]int64, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.MessageID
+	}
+	// 不依赖索引的实现，再次确认消息在account的会话中
+	var indexes []database.MessageIndex
+	err = h.MessageDb.Where("account_a=? and message_id in ?", req.Account, ids).Find(&indexes).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) > 0 && len(indexes) < len(ids) {
+		// 读扩散的群中的消息在群的时间线中，只能搜索到加入之后的消息
+		var entries []database.GroupTimeline
+		err = h.MessageDb.Where("message_id in ?", ids).Scopes(afterJoined(groups, 0, "")).Find(&entries).Error
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, timelineToIndexes(req.Account, entries)...)
+	}
+	var contents []database.MessageCount
+	if err = h.MessageDb.Where("id in ? and recalled=?", ids, false).Find(&contents).Error; err != nil {
+		return nil, err
+	}
+	indexMap := make(map[int64]*database.MessageIndex, len(indexes))
+	for i := range indexes {
+		indexMap[indexes[i].MessageID] = &indexes[i]
+	}
+	contentMap := make(map[int64]*database.MessageCount, len(contents))
+	for i := range contents {
+		contentMap[contents[i].ID] = &contents[i]
+	}
+	resp.Hits = make([]*rpc.SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		index, ok := indexMap[hit.MessageID]
+		if !ok {
+			continue
+		}
+		content, ok := contentMap[hit.MessageID]
+		if !ok {
+			continue
+		}
+		item := &rpc.SearchHit{
+			MessageId: hit.MessageID,
+			Sender:    index.AccountB,
+			Dest:      index.AccountB,
+			SendTime:  index.SendTime,
+			Type:      int32(content.Type),
+			Body:      content.Body,
+			Extra:     content.Extra,
+		}
+		if index.Direction == 1 {
+			item.Sender = req.Account
+		}
+		if index.Group != "" {
+			item.Dest = index.Group
+			item.Group = true
+		}
+		resp.Hits = append(resp.Hits, item)
+	}
+	return resp, nil
+}
+
+// index 更新搜索索引，失败时只记录日志，不影响消息的写入
+func (h *ServiceHandle) index(doc *search.Document) {
+	if h.Searcher == nil {
+		return
+	}
+	if err := h.Searcher.Index(doc); err != nil {
+		logger.Warnf("index message %d failed: %v", doc.MessageID, err)
+	}
+}
+
+func (h *ServiceHandle) unindex(messageId int64) {
+	if h.Searcher == nil {
+		return
+	}
+	if err := h.Searcher.Remove(messageId); err != nil {
+		logger.Warnf("remove index of message %d failed: %v", messageId, err)
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kingim/services/service/search"
+	"kingim/wire/rpc"
+)
+
+func TestMessageSearch(t *testing.T) {
+	h, mock := newTestHandle(t)
+	searcher := &fakeSearcher{hits: []search.Hit{
+		{MessageID: 3, SendTime: 300},
+		{MessageID: 2, SendTime: 200},
+		{MessageID: 1, SendTime: 100},
+	}}
+	h.Searcher = searcher
+	joined := time.Unix(0, 150)
+	mock.ExpectQuery("FROM t_group_member gm JOIN t_group g").
+		WillReturnRows(sqlmock.NewRows([]string{"group", "created_at"}).AddRow("g1", joined))
+	// 不依赖Searcher的实现，再次确认消息在账号的会话中
+	mock.ExpectQuery("FROM `t_message_index` WHERE account_a=\\? and message_id in \\(\\?,\\?\\)").WithArgs("test1", 3, 2).
+		WillReturnRows(sqlmock.NewRows(indexColumns).AddRow(1, "test1", "test2", 0, 3, "", 300))
+	mock.ExpectQuery("FROM `t_group_timeline` WHERE message_id in \\(\\?,\\?\\) AND \\(\\(\\(`group`=\\? and send_time>\\?\\)\\)\\)").
+		WithArgs(3, 2, "g1", joined.UnixNano()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "group", "sender", "message_id", "send_time"}).AddRow(5, "g1", "test3", 2, 200))
+	mock.ExpectQuery("FROM `t_message_count` WHERE id in \\(\\?,\\?\\) and recalled=\\?").WithArgs(3, 2, false).
+		WillReturnRows(sqlmock.NewRows(contentColumns).
+			AddRow(3, 1, "hello", "", 300, false, 0, 0).
+			AddRow(2, 1, "hello all", "", 200, false, 0, 0))
+
+	var resp rpc.SearchMessageResp
+	code := serve(t, h.MessageSearch, &rpc.SearchMessageReq{Account: "test1", Keyword: "hello", Limit: 2}, &resp)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"g1"}, searcher.query.Groups)
+	// 多取一条用于判断是否还有更多
+	assert.Equal(t, 3, searcher.query.Limit)
+	assert.Equal(t, int64(200), resp.NextCursor)
+	require.Equal(t, 2, len(resp.Hits))
+	assert.Equal(t, int64(3), resp.Hits[0].MessageId)
+	assert.Equal(t, "test2", resp.Hits[0].Dest)
+	assert.Equal(t, "test2", resp.Hits[0].Sender)
+	assert.False(t, resp.Hits[0].Group)
+	assert.Equal(t, int64(2), resp.Hits[1].MessageId)
+	assert.Equal(t, "g1", resp.Hits[1].Dest)
+	assert.Equal(t, "test3", resp.Hits[1].Sender)
+	assert.True(t, resp.Hits[1].Group)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageSearch_BadRequest(t *testing.T) {
+	h, mock := newTestHandle(t)
+	code := serve(t, h.MessageSearch, &rpc.SearchMessageReq{Account: "test1"}, nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code = serve(t, h.MessageSearch, &rpc.SearchMessageReq{Account: "test1", Keyword: "hi", Peer: "test2", Group: "g1"}, nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
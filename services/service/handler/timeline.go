@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"kingim/logger"
+	"kingim/services/service/database"
+	"kingim/wire/rpc"
+)
+
+// timelineGroup 账号所在的读扩散的群，成员只能读取加入之后的消息
+type timelineGroup struct {
+	Group    string
+	JoinedAt int64
+}
+
+// insertTimeline 读扩散的群每条消息只写一条时间线和发送方的会话，
+// 其它成员的最后一条消息和未读数在读取时从时间线和已读位置中统计
+func (h *ServiceHandle) insertTimeline(tx *gorm.DB, messageId int64, req *rpc.InsertMessageReq) error {
+	entry := database.GroupTimeline{
+		ID:        h.Idgen.Next().Int64(),
+		Group:     req.Dest,
+		Sender:    req.Sender,
+		MessageID: messageId,
+		SendTime:  req.SendTime,
+	}
+	if err := tx.Create(&entry).Error; err != nil {
+		return err
+	}
+	conv := newConversation(h.Idgen.Next().Int64(), req.Sender, req.Dest, true, messageId, req)
+	return upsertConversations(tx, []database.Conversation{conv})
+}
+
+// joinConversations 读扩散的群中成员的会话在加入群时创建
+func (h *ServiceHandle) joinConversations(groupId string, members []database.GroupMember) {
+	convs := make([]database.Conversation, len(members))
+	for i, m := range members {
+		convs[i] = database.Conversation{
+			ID:      h.Idgen.Next().Int64(),
+			Account: m.Account,
+			Dest:    groupId,
+			Group:   true,
+		}
+	}
+	err := h.MessageDb.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(convs, 500).Error
+	if err != nil {
+		logger.Warnf("create conversations of group %s failed: %v", groupId, err)
+	}
+}
+
+// leaveConversation 读扩散的群中退出的成员删除会话，重新加入时只能读取加入之后的消息
+func (h *ServiceHandle) leaveConversation(groupId, account string) {
+	err := h.MessageDb.Where("account=? and dest=? and `group`=?", account, groupId, true).Delete(&database.Conversation{}).Error
+	if err != nil {
+		logger.Warnf("delete conversation of group %s for %s failed: %v", groupId, account, err)
+	}
+}
+
+// timelineGroups account所在的读扩散的群和加入的时间
+func (h *ServiceHandle) timelineGroups(account string) ([]timelineGroup, error) {
+	var members []database.GroupMember
+	err := h.BaseDb.Table("t_group_member gm").
+		Select("gm.`group`", "gm.created_at").
+		Joins("JOIN t_group g ON g.`group`=gm.`group`").
+		Where("gm.account=? and g.read_diffusion=?", account, true).
+		Scan(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]timelineGroup, len(members))
+	for i, m := range members {
+		groups[i] = timelineGroup{Group: m.Group, JoinedAt: m.CreatedAt.UnixNano()}
+	}
+	return groups, nil
+}
+
+// joinedAt account加入群的时间，不是当前的群成员时返回gorm.ErrRecordNotFound
+func (h *ServiceHandle) joinedAt(groupId, account string) (int64, error) {
+	var member database.GroupMember
+	err := h.BaseDb.Select("created_at").Where("`group`=? and account=?", groupId, account).First(&member).Error
+	if err != nil {
+		return 0, err
+	}
+	return member.CreatedAt.UnixNano(), nil
+}
+
+// afterJoined 只保留groups中晚于start和成员加入时间的时间线，prefix为时间线表的别名
+func afterJoined(groups []timelineGroup, start int64, prefix string) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if len(groups) == 0 {
+			return tx.Where("1=0")
+		}
+		conds := make([]string, len(groups))
+		args := make([]interface{}, 0, len(groups)*2)
+		for i, g := range groups {
+			conds[i] = "(" + prefix + "`group`=? and " + prefix + "send_time>?)"
+			since := g.JoinedAt
+			if start > since {
+				since = start
+			}
+			args = append(args, g.Group, since)
+		}
+		return tx.Where("("+strings.Join(conds, " or ")+")", args...)
+	}
+}
+
+// groupIds 时间线的群ID
+func groupIds(groups []timelineGroup) []string {
+	ids := make([]string, len(groups))
+	for i, g := range groups {
+		ids[i] = g.Group
+	}
+	return ids
+}
+
+// timelineIndexes 读取groups的时间线中晚于start的消息，自己发送的消息不需要同步
+func (h *ServiceHandle) timelineIndexes(account string, groups []timelineGroup, start int64, limit int) ([]*rpc.MessageIndex, error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	var entries []database.GroupTimeline
+	err := h.MessageDb.Where("sender<>?", account).Scopes(afterJoined(groups, start, "")).
+		Order("send_time asc").Limit(limit).Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([]*rpc.MessageIndex, len(entries))
+	for i, entry := range entries {
+		indexes[i] = &rpc.MessageIndex{
+			MessageId: entry.MessageID,
+			SendTime:  entry.SendTime,
+			AccountB:  entry.Sender,
+			Group:     entry.Group,
+		}
+	}
+	return indexes, nil
+}
+
+// timelineUnreads 统计groups中晚于已读位置和加入时间的消息数
+func (h *ServiceHandle) timelineUnreads(account string, groups []timelineGroup, since int64) ([]*rpc.UnreadCount, error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	var unreads []*rpc.UnreadCount
+	err := h.MessageDb.Table("t_group_timeline gt").
+		Select("gt.`group` AS dest, TRUE AS `group`, COUNT(*) AS count, MAX(IFNULL(rc.message_id, 0)) AS read_message_id").
+		Joins("LEFT JOIN t_read_cursor rc ON rc.account=? AND rc.dest=gt.`group` AND rc.`group`=TRUE", account).
+		Scopes(afterJoined(groups, since, "gt.")).
+		Where("gt.sender<>? AND gt.send_time>IFNULL(rc.send_time, 0)", account).
+		Group("gt.`group`").Scan(&unreads).Error
+	if err != nil {
+		return nil, err
+	}
+	return unreads, nil
+}
+
+// timelineLasts groups中每个群加入之后的最后一条消息
+func (h *ServiceHandle) timelineLasts(groups []timelineGroup) ([]database.GroupTimeline, error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	lasts := h.MessageDb.Model(&database.GroupTimeline{}).
+		Select("`group`", "MAX(send_time) AS send_time").
+		Scopes(afterJoined(groups, 0, "")).Group("`group`")
+	var entries []database.GroupTimeline
+	err := h.MessageDb.Table("t_group_timeline gt").Select("gt.*").
+		Joins("JOIN (?) l ON l.`group`=gt.`group` AND l.send_time=gt.send_time", lasts).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// timelineMessage 读扩散的群中消息的时间线，不在这个群中时返回gorm.ErrRecordNotFound
+func (h *ServiceHandle) timelineMessage(groupId string, messageId int64) (*database.GroupTimeline, error) {
+	var entry database.GroupTimeline
+	err := h.MessageDb.Where("`group`=? and message_id=?", groupId, messageId).First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// timelineSendTime account可以看到的时间线中消息的发送时间，不是群成员或者消息在加入之前发送时返回gorm.ErrRecordNotFound
+func (h *ServiceHandle) timelineSendTime(groupId, account string, messageId int64) (int64, error) {
+	joined, err := h.joinedAt(groupId, account)
+	if err != nil {
+		return 0, err
+	}
+	entry, err := h.timelineMessage(groupId, messageId)
+	if err != nil {
+		return 0, err
+	}
+	if entry.SendTime <= joined {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return entry.SendTime, nil
+}
+
+// mergeIndexes 按发送时间合并两个有序的索引列表，最多保留limit条
+func mergeIndexes(a, b []*rpc.MessageIndex, limit int) []*rpc.MessageIndex {
+	if len(b) == 0 {
+		return a
+	}
+	list := append(a, b...)
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].SendTime < list[j].SendTime
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// mergeUnreads 改为读扩散之前的消息还在索引中，同一个群的未读数需要相加
+func mergeUnreads(a, b []*rpc.UnreadCount) []*rpc.UnreadCount {
+	groups := make(map[string]*rpc.UnreadCount)
+	for _, u := range a {
+		if u.Group {
+			groups[u.Dest] = u
+		}
+	}
+	for _, u := range b {
+		if exist, ok := groups[u.Dest]; ok {
+			exist.Count += u.Count
+			if u.ReadMessageId > exist.ReadMessageId {
+				exist.ReadMessageId = u.ReadMessageId
+			}
+			continue
+		}
+		a = append(a, u)
+	}
+	return a
+}
+
+// timelineToIndexes 把时间线转换成account的消息索引
+func timelineToIndexes(account string, entries []database.GroupTimeline) []database.MessageIndex {
+	indexes := make([]database.MessageIndex, len(entries))
+	for i, entry := range entries {
+		indexes[i] = database.MessageIndex{
+			ID:        entry.ID,
+			AccountA:  account,
+			AccountB:  entry.Sender,
+			MessageID: entry.MessageID,
+			Group:     entry.Group,
+			SendTime:  entry.SendTime,
+		}
+		if entry.Sender == account {
+			indexes[i].Direction = 1
+		}
+	}
+	return indexes
+}
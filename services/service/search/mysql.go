@@ -0,0 +1,118 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// MysqlSearcher 使用t_message_count.body上的FULLTEXT索引(ngram分词)搜索
+type MysqlSearcher struct {
+	db *gorm.DB
+}
+
+func NewMysqlSearcher(db *gorm.DB) Searcher {
+	return &MysqlSearcher{db: db}
+}
+
+// Index 消息表上的FULLTEXT索引由MySQL维护
+func (s *MysqlSearcher) Index(*Document) error {
+	return nil
+}
+
+// Remove 撤回的消息在搜索时通过recalled字段过滤
+func (s *MysqlSearcher) Remove(int64) error {
+	return nil
+}
+
+func (s *MysqlSearcher) Search(q *Query) ([]Hit, error) {
+	keyword := strings.TrimSpace(q.Keyword)
+	if keyword == "" {
+		return nil, ErrEmptyKeyword
+	}
+	tx := s.db.Table("t_message_index mi").
+		Select("mi.message_id, mi.send_time").
+		Joins("JOIN t_message_count mc ON mc.id=mi.message_id").
+		Where("mi.account_a=? and mc.recalled=?", q.Account, false).
+		Where("MATCH(mc.body) AGAINST(? IN BOOLEAN MODE)", phrase(keyword))
+	if q.Peer != "" {
+		tx = tx.Where("mi.account_b=? and mi.`group`=''", q.Peer)
+	}
+	if q.Group != "" {
+		tx = tx.Where("mi.`group`=?", q.Group)
+	}
+	var hits []Hit
+	err := tx.Scopes(filter(q, "mi")).Scan(&hits).Error
+	if err != nil {
+		return nil, err
+	}
+	groups := timelineGroups(q)
+	if len(groups) == 0 {
+		return hits, nil
+	}
+	var timeline []Hit
+	err = s.db.Table("t_group_timeline gt").
+		Select("gt.message_id, gt.send_time").
+		Joins("JOIN t_message_count mc ON mc.id=gt.message_id").
+		Where("gt.`group` in ? and mc.recalled=?", groups, false).
+		Where("MATCH(mc.body) AGAINST(? IN BOOLEAN MODE)", phrase(keyword)).
+		Scopes(filter(q, "gt")).Scan(&timeline).Error
+	if err != nil {
+		return nil, err
+	}
+	hits = append(hits, timeline...)
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].SendTime > hits[j].SendTime
+	})
+	if len(hits) > q.Limit {
+		hits = hits[:q.Limit]
+	}
+	return hits, nil
+}
+
+// filter 消息类型、时间范围、游标和排序，table是索引或者时间线的别名
+func filter(q *Query, table string) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if len(q.Types) > 0 {
+			tx = tx.Where("mc.type in ?", q.Types)
+		}
+		if q.StartTime > 0 {
+			tx = tx.Where(table+".send_time>=?", q.StartTime)
+		}
+		if q.EndTime > 0 {
+			tx = tx.Where(table+".send_time<?", q.EndTime)
+		}
+		if q.Before > 0 {
+			tx = tx.Where(table+".send_time<?", q.Before)
+		}
+		return tx.Order(table + ".send_time desc").Limit(q.Limit)
+	}
+}
+
+// timelineGroups 需要搜索时间线的群，只搜索单聊时为空
+func timelineGroups(q *Query) []string {
+	if q.Peer != "" {
+		return nil
+	}
+	if q.Group == "" {
+		return q.Groups
+	}
+	for _, g := range q.Groups {
+		if g == q.Group {
+			return []string{g}
+		}
+	}
+	return nil
+}
+
+// phrase 把关键字作为一个短语搜索，去掉关键字中BOOLEAN MODE的操作符
+func phrase(keyword string) string {
+	keyword = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`"+-<>()~*@`, r) {
+			return ' '
+		}
+		return r
+	}, keyword)
+	return `"` + keyword + `"`
+}
@@ -0,0 +1,90 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestSearcher(t *testing.T) (Searcher, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewMysqlSearcher(db), mock
+}
+
+func TestMysqlSearcher_Search(t *testing.T) {
+	s, mock := newTestSearcher(t)
+	mock.ExpectQuery("FROM t_message_index mi JOIN t_message_count mc ON mc.id=mi.message_id "+
+		"WHERE \\(mi.account_a=\\? and mc.recalled=\\?\\) AND MATCH\\(mc.body\\) AGAINST\\(\\? IN BOOLEAN MODE\\) "+
+		"AND mc.type in \\(\\?\\) AND mi.send_time<\\? ORDER BY mi.send_time desc LIMIT 2").
+		WithArgs("test1", false, `"hello  world"`, 1, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "send_time"}).AddRow(4, 400).AddRow(2, 200))
+	mock.ExpectQuery("FROM t_group_timeline gt JOIN t_message_count mc ON mc.id=gt.message_id "+
+		"WHERE \\(gt.`group` in \\(\\?,\\?\\) and mc.recalled=\\?\\) AND MATCH\\(mc.body\\) AGAINST\\(\\? IN BOOLEAN MODE\\) "+
+		"AND mc.type in \\(\\?\\) AND gt.send_time<\\? ORDER BY gt.send_time desc LIMIT 2").
+		WithArgs("g1", "g2", false, `"hello  world"`, 1, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "send_time"}).AddRow(3, 300))
+
+	hits, err := s.Search(&Query{
+		Account: "test1",
+		Keyword: " hello +world ",
+		Groups:  []string{"g1", "g2"},
+		Types:   []int32{1},
+		Before:  500,
+		Limit:   2,
+	})
+	assert.Nil(t, err)
+	// 索引和时间线的结果按发送时间倒序合并
+	assert.Equal(t, []Hit{{MessageID: 4, SendTime: 400}, {MessageID: 3, SendTime: 300}}, hits)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlSearcher_Peer(t *testing.T) {
+	s, mock := newTestSearcher(t)
+	// 只搜索单聊时不搜索群的时间线
+	mock.ExpectQuery("FROM t_message_index mi .* AND \\(mi.account_b=\\? and mi.`group`=''\\) AND mi.send_time>=\\? AND mi.send_time<\\?").
+		WithArgs("test1", false, `"hi"`, "test2", 100, 200).
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "send_time"}).AddRow(1, 150))
+
+	hits, err := s.Search(&Query{Account: "test1", Keyword: "hi", Peer: "test2", Groups: []string{"g1"}, StartTime: 100, EndTime: 200, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, []Hit{{MessageID: 1, SendTime: 150}}, hits)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlSearcher_EmptyKeyword(t *testing.T) {
+	s, mock := newTestSearcher(t)
+	_, err := s.Search(&Query{Account: "test1", Keyword: "  ", Limit: 10})
+	assert.Equal(t, ErrEmptyKeyword, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_timelineGroups(t *testing.T) {
+	groups := []string{"g1", "g2"}
+	assert.Equal(t, groups, timelineGroups(&Query{Groups: groups}))
+	assert.Nil(t, timelineGroups(&Query{Peer: "test2", Groups: groups}))
+	assert.Equal(t, []string{"g2"}, timelineGroups(&Query{Group: "g2", Groups: groups}))
+	// 写扩散的群只在索引中搜索
+	assert.Nil(t, timelineGroups(&Query{Group: "g3", Groups: groups}))
+}
+
+func Test_phrase(t *testing.T) {
+	assert.Equal(t, `"hello"`, phrase("hello"))
+	assert.Equal(t, `" a  b  c "`, phrase(`"a"+b-<c>`))
+	assert.Equal(t, `"你好"`, phrase("你好"))
+}
@@ -0,0 +1,53 @@
+package search
+
+import "errors"
+
+// ErrEmptyKeyword 搜索的关键字为空
+var ErrEmptyKeyword = errors.New("keyword is empty")
+
+// Document 需要建立索引的消息
+type Document struct {
+	MessageID int64
+	Sender    string
+	Dest      string // 单聊为接收方账号，群聊为群ID
+	Group     bool
+	Type      int32
+	Body      string
+	SendTime  int64
+}
+
+// Query 搜索条件，只在Account参与的会话中搜索
+type Query struct {
+	Account string
+	Keyword string
+	// Peer 只搜索和这个账号的单聊，不能和Group同时使用
+	Peer string
+	// Group 只搜索这个群的消息
+	Group string
+	// Groups Account所在的读扩散的群，这些群的消息从群的时间线中搜索
+	Groups []string
+	// Types 消息类型，为空表示不限制
+	Types []int32
+	// StartTime和EndTime 发送时间的范围[StartTime, EndTime)，为0表示不限制
+	StartTime int64
+	EndTime   int64
+	// Before 游标，只返回在这个时间之前发送的消息
+	Before int64
+	Limit  int
+}
+
+// Hit 命中的消息，按发送时间倒序返回
+type Hit struct {
+	MessageID int64
+	SendTime  int64
+}
+
+// Searcher 消息的全文索引，MySQL的实现直接使用消息表上的FULLTEXT索引，
+// 其它实现(例如本地的bleve索引)需要在消息写入、修改和撤回时维护自己的索引
+type Searcher interface {
+	// Index 添加或者更新一条消息的索引
+	Index(doc *Document) error
+	// Remove 删除一条消息的索引，例如消息被撤回
+	Remove(messageId int64) error
+	Search(q *Query) ([]Hit, error)
+}
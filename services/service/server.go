@@ -12,6 +12,7 @@ import (
 	"kingim/services/service/conf"
 	"kingim/services/service/database"
 	"kingim/services/service/handler"
+	"kingim/services/service/search"
 	"kingim/wire"
 )
 
@@ -45,12 +46,16 @@ func RunServerStart(ctx context.Context, opts *ServerStartOptions, version strin
 	if err != nil {
 		return err
 	}
-	_ = db.AutoMigrate(&database.Group{}, &database.GroupMember{})
+	// 联系人的唯一索引加入了app，删除旧的索引
+	if db.Migrator().HasIndex(&database.Contact{}, "uni_acc_peer") {
+		_ = db.Migrator().DropIndex(&database.Contact{}, "uni_acc_peer")
+	}
+	_ = db.AutoMigrate(&database.Group{}, &database.GroupMember{}, &database.Contact{}, &database.FriendRequest{}, &database.GroupJoinRequest{})
 	messageDb, err := database.InitMysqlDb(config.MessageDb)
 	if err != nil {
 		return err
 	}
-	_ = messageDb.AutoMigrate(&database.MessageIndex{}, &database.MessageCount{})
+	_ = messageDb.AutoMigrate(&database.MessageIndex{}, &database.MessageCount{}, &database.ReadCursor{}, &database.Conversation{}, &database.MessageRevision{}, &database.GroupTimeline{})
 	if config.NodeID == 0 {
 		config.NodeID = int64(HashCode(config.ServiceID))
 	}
@@ -85,6 +90,9 @@ func RunServerStart(ctx context.Context, opts *ServerStartOptions, version strin
 		MessageDb: messageDb,
 		Idgen: idgen,
 		Cache: rdb,
+		RecallWindow: config.RecallWindow,
+		LargeGroupThreshold: config.LargeGroupThreshold,
+		Searcher: search.NewMysqlSearcher(messageDb),
 	}
 	ac := conf.MakeAccessLog()
 	defer ac.Close()
@@ -105,6 +113,9 @@ func newApp(handle *handler.ServiceHandle) *iris.Application {
 		messageAPI.Post("/user",handle.InsertUserMessage)
 		messageAPI.Post("/group", handle.InsertGroupMessage)
 		messageAPI.Post("/ack", handle.MessageAck)
+		messageAPI.Post("/read", handle.MessageRead)
+		messageAPI.Post("/recall", handle.MessageRecall)
+		messageAPI.Post("/edit", handle.MessageEdit)
 	}
 	groupAPI := app.Party("/api/:app/group")
 	{
@@ -113,6 +124,34 @@ func newApp(handle *handler.ServiceHandle) *iris.Application {
 		groupAPI.Post("/member", handle.GroupJoin)
 		groupAPI.Delete("/member", handle.GroupQuit)
 		groupAPI.Get("/members/:id", handle.GroupMembers)
+		groupAPI.Post("/member/kick", handle.GroupKick)
+		groupAPI.Post("/member/role", handle.GroupSetRole)
+		groupAPI.Post("/transfer", handle.GroupTransfer)
+		groupAPI.Post("/joinmode", handle.GroupSetJoinMode)
+		groupAPI.Delete("", handle.GroupDissolve)
+		groupAPI.Post("/mute", handle.GroupMute)
+		groupAPI.Post("/member/mute", handle.GroupMuteMember)
+		groupAPI.Post("/announcement", handle.GroupSetAnnouncement)
+		groupAPI.Post("/join/requests", handle.GroupJoinRequests)
+		groupAPI.Post("/join/handle", handle.GroupHandleJoin)
+	}
+	app.Post("/api/:app/history", handle.GetHistory)
+	app.Post("/api/:app/search", handle.MessageSearch)
+	conversationAPI := app.Party("/api/:app/conversation")
+	{
+		conversationAPI.Post("/list", handle.ConversationList)
+		conversationAPI.Post("/update", handle.ConversationUpdate)
+	}
+	contactAPI := app.Party("/api/:app/contact")
+	{
+		contactAPI.Post("/request", handle.ContactRequest)
+		contactAPI.Post("/request/handle", handle.ContactHandleRequest)
+		contactAPI.Get("/requests/:account", handle.ContactRequests)
+		contactAPI.Get("/list/:account", handle.ContactList)
+		contactAPI.Delete("/friend", handle.ContactRemove)
+		contactAPI.Post("/block", handle.ContactBlock)
+		contactAPI.Post("/remark", handle.ContactRemark)
+		contactAPI.Get("/relation/:account/:peer", handle.ContactRelation)
 	}
 	offlineAPI := app.Party("/api/:app/offline")
 	{
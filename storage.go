@@ -13,12 +13,108 @@ var ErrSessionNil = errors.New("err:session nil")
 type SessionStorage interface {
 	// Add a session
 	Add(session *pkt.Session) error
-	// Delete a session
+	// Delete a session, only the location of the device used by this channel is removed
 	Delete(account string, channelId string) error
 	// Get session by channelId
 	Get(channelId string) (*pkt.Session, error)
-	// Get Locations by accounts
+	// Get Locations by accounts, including every online device of each account
 	GetLocations(account ...string) ([]*Location, error)
-	// Get Location by account and device
+	// Get Location by account and device, an empty device returns the latest login
 	GetLocation(account string, device string) (*Location, error)
+	// Refresh renews the ttl of a session on gateId and the locations of its account,
+	// a session on another gateway is treated as not found
+	Refresh(gateId string, channelId string) error
+	// DeleteByGate deletes all sessions on a gateway, returns the number of deleted sessions
+	DeleteByGate(gateId string) (int, error)
+	// GetByGate returns all sessions on a gateway
+	GetByGate(gateId string) ([]*pkt.Session, error)
+	// GetByTags returns the sessions of app that logged in with any of tags
+	GetByTags(app string, tags ...string) ([]*pkt.Session, error)
+}
+
+// Presence 账号的自定义状态和最后在线时间，是否在线由SessionStorage中的位置信息决定
+type Presence struct {
+	Account string
+	// Status 自定义状态，如away、busy，空表示没有设置
+	Status string
+	// LastSeen 最后一个设备下线的时间，单位毫秒
+	LastSeen int64
+}
+
+// PresenceStorage 保存账号的状态和订阅关系，不同app的账号互相隔离
+type PresenceStorage interface {
+	// SetStatus sets the custom status of an account
+	SetStatus(app string, account string, status string) error
+	// SetLastSeen sets the time the account went offline, in milliseconds
+	SetLastSeen(app string, account string, lastSeen int64) error
+	// GetPresences returns one presence for each account, accounts never seen have zero values
+	GetPresences(app string, accounts ...string) ([]*Presence, error)
+	// Subscribe lets subscriber receive presence changes of accounts
+	Subscribe(app string, subscriber string, accounts ...string) error
+	// Unsubscribe stops subscriber from receiving presence changes of accounts
+	Unsubscribe(app string, subscriber string, accounts ...string) error
+	// Subscribers returns the accounts subscribing to the presence of account
+	Subscribers(app string, account string) ([]string, error)
+	// SetOnline schedules a check of whether account is still online at checkAt, in milliseconds
+	SetOnline(app string, account string, checkAt int64) error
+	// RemoveOnline cancels the check after account went offline
+	RemoveOnline(app string, account string) error
+	// ClaimExpired removes and returns at most count accounts whose check is due at now,
+	// an account is only returned to one of the callers
+	ClaimExpired(now int64, count int) ([]*OnlineAccount, error)
+}
+
+// OnlineAccount 需要检查是否已经离线的账号，会话可能过期而没有登出
+type OnlineAccount struct {
+	App     string
+	Account string
+}
+
+// RoomStorage 保存房间中的成员，成员按所在的网关分组，房间消息每个网关只推送一次
+type RoomStorage interface {
+	// Join adds the channel on gateId to room
+	Join(room string, gateId string, channelId string) error
+	// Leave removes the channel from room, the gateway is removed when it has no members in room
+	Leave(room string, gateId string, channelId string) error
+	// LeaveAll removes the channel from every room it joined, returns these rooms
+	LeaveAll(gateId string, channelId string) ([]string, error)
+	// Joined returns whether the channel is a member of room
+	Joined(room string, gateId string, channelId string) (bool, error)
+	// Gateways returns the gateways that have members in room
+	Gateways(room string) ([]string, error)
+	// DeleteByGate removes all members on a gateway from every room, returns the number of rooms it was in
+	DeleteByGate(gateId string) (int, error)
+	// Refresh renews the rooms joined by the channels on gateId
+	Refresh(gateId string, channelIds ...string) error
+}
+
+// PendingAck 已经推送但是接收方还没有确认的消息
+type PendingAck struct {
+	App       string `json:"app"`
+	Account   string `json:"account"`
+	MessageId int64  `json:"message_id"`
+	// Command、Dest 和 Flag 用于重推时构造和原消息相同的包头
+	Command string   `json:"command"`
+	Dest    string   `json:"dest"`
+	Flag    pkt.Flag `json:"flag"`
+	Sender  string   `json:"sender"`
+	// Push 序列化之后的MessagePush
+	Push    []byte `json:"push"`
+	Retries int    `json:"retries"`
+}
+
+// AckStorage 保存等待ack的消息，chat服务的所有节点共享，ack可以由任意节点确认
+type AckStorage interface {
+	// Add saves ack if it is not pending yet, it is due for retry at due in milliseconds
+	Add(ack *PendingAck, due int64) (bool, error)
+	// Remove removes the pending ack of account in app and returns it, returns nil if it is not pending
+	Remove(app string, account string, messageId int64) (*PendingAck, error)
+	// RemoveMessage removes the pending acks of every receiver of the message
+	RemoveMessage(messageId int64) error
+	// Claim takes at most count acks due before now, a claimed ack is not returned again until it is scheduled by Schedule
+	Claim(now int64, count int) ([]*PendingAck, error)
+	// Schedule puts a claimed ack back, it is due for retry at due in milliseconds. Nothing is done if it has been removed
+	Schedule(ack *PendingAck, due int64) error
+	// Len returns the number of acks waiting for retry
+	Len() (int, error)
 }
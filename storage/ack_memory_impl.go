@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+
+	"kingim"
+)
+
+type ackKey struct {
+	app       string
+	account   string
+	messageId int64
+}
+
+type memoryAck struct {
+	ack     kingim.PendingAck
+	due     int64
+	claimed bool
+}
+
+// MemoryAckStorage 基于内存的AckStorage，用于单节点部署和测试
+type MemoryAckStorage struct {
+	sync.Mutex
+	pending map[ackKey]*memoryAck
+}
+
+func NewMemoryAckStorage() kingim.AckStorage {
+	return &MemoryAckStorage{
+		pending: make(map[ackKey]*memoryAck),
+	}
+}
+
+func (m *MemoryAckStorage) Add(ack *kingim.PendingAck, due int64) (bool, error) {
+	m.Lock()
+	defer m.Unlock()
+	key := ackKey{app: ack.App, account: ack.Account, messageId: ack.MessageId}
+	if _, ok := m.pending[key]; ok {
+		return false, nil
+	}
+	m.pending[key] = &memoryAck{ack: *ack, due: due}
+	return true, nil
+}
+
+func (m *MemoryAckStorage) Remove(app string, account string, messageId int64) (*kingim.PendingAck, error) {
+	m.Lock()
+	defer m.Unlock()
+	key := ackKey{app: app, account: account, messageId: messageId}
+	p, ok := m.pending[key]
+	if !ok {
+		return nil, nil
+	}
+	delete(m.pending, key)
+	ack := p.ack
+	return &ack, nil
+}
+
+func (m *MemoryAckStorage) RemoveMessage(messageId int64) error {
+	m.Lock()
+	defer m.Unlock()
+	for key := range m.pending {
+		if key.messageId == messageId {
+			delete(m.pending, key)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryAckStorage) Claim(now int64, count int) ([]*kingim.PendingAck, error) {
+	m.Lock()
+	defer m.Unlock()
+	var due []*memoryAck
+	for _, p := range m.pending {
+		if !p.claimed && p.due <= now {
+			due = append(due, p)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].due < due[j].due
+	})
+	if len(due) > count {
+		due = due[:count]
+	}
+	acks := make([]*kingim.PendingAck, len(due))
+	for i, p := range due {
+		p.claimed = true
+		ack := p.ack
+		acks[i] = &ack
+	}
+	return acks, nil
+}
+
+func (m *MemoryAckStorage) Schedule(ack *kingim.PendingAck, due int64) error {
+	m.Lock()
+	defer m.Unlock()
+	p, ok := m.pending[ackKey{app: ack.App, account: ack.Account, messageId: ack.MessageId}]
+	if !ok {
+		return nil
+	}
+	p.ack = *ack
+	p.due = due
+	p.claimed = false
+	return nil
+}
+
+func (m *MemoryAckStorage) Len() (int, error) {
+	m.Lock()
+	defer m.Unlock()
+	count := 0
+	for _, p := range m.pending {
+		if !p.claimed {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+	"kingim"
+)
+
+// AckExpired 等待ack的消息的保存时间，正常情况下重试次数用完之前就会被删除，
+// 过期用于清理节点在Claim和Schedule之间宕机时留下的数据
+const AckExpired = time.Hour
+
+// KeyAckDue 按重推时间排序的等待ack的消息
+const KeyAckDue = "ack:due"
+
+// addAckScript 消息不在等待ack时才保存，避免重复的Track重置重试次数
+var addAckScript = redis.NewScript(`
+if not redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return 0
+end
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[4])
+redis.call('SADD', KEYS[3], ARGV[5])
+redis.call('PEXPIRE', KEYS[3], ARGV[2])
+return 1
+`)
+
+// scheduleAckScript 已经被确认的消息不能重新放回去
+var scheduleAckScript = redis.NewScript(`
+if not redis.call('SET', KEYS[1], ARGV[1], 'XX', 'PX', ARGV[2]) then
+	return 0
+end
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[4])
+return 1
+`)
+
+type RedisAckStorage struct {
+	cli *redis.Client
+}
+
+func NewRedisAckStorage(cli *redis.Client) kingim.AckStorage {
+	return &RedisAckStorage{cli: cli}
+}
+
+func (r *RedisAckStorage) Add(ack *kingim.PendingAck, due int64) (bool, error) {
+	bts, err := json.Marshal(ack)
+	if err != nil {
+		return false, err
+	}
+	keys := []string{KeyAckPending(ack.App, ack.Account, ack.MessageId), KeyAckDue, KeyAckMessage(ack.MessageId)}
+	added, err := addAckScript.Run(r.cli, keys, bts, AckExpired.Milliseconds(), due, ackMember(ack.App, ack.Account, ack.MessageId), receiverMember(ack.App, ack.Account)).Int()
+	if err != nil {
+		return false, err
+	}
+	return added == 1, nil
+}
+
+func (r *RedisAckStorage) Remove(app string, account string, messageId int64) (*kingim.PendingAck, error) {
+	pipe := r.cli.TxPipeline()
+	get := pipe.Get(KeyAckPending(app, account, messageId))
+	pipe.Del(KeyAckPending(app, account, messageId))
+	pipe.ZRem(KeyAckDue, ackMember(app, account, messageId))
+	pipe.SRem(KeyAckMessage(messageId), receiverMember(app, account))
+	_, err := pipe.Exec()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeAck(get.Val())
+}
+
+func (r *RedisAckStorage) RemoveMessage(messageId int64) error {
+	receivers, err := r.cli.SMembers(KeyAckMessage(messageId)).Result()
+	if err != nil {
+		return err
+	}
+	pipe := r.cli.TxPipeline()
+	for _, receiver := range receivers {
+		app, account, ok := parseReceiverMember(receiver)
+		if !ok {
+			continue
+		}
+		pipe.Del(KeyAckPending(app, account, messageId))
+		pipe.ZRem(KeyAckDue, ackMember(app, account, messageId))
+	}
+	pipe.Del(KeyAckMessage(messageId))
+	_, err = pipe.Exec()
+	return err
+}
+
+func (r *RedisAckStorage) Claim(now int64, count int) ([]*kingim.PendingAck, error) {
+	members, err := r.cli.ZRangeByScore(KeyAckDue, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now, 10),
+		Count: int64(count),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	acks := make([]*kingim.PendingAck, 0, len(members))
+	for _, member := range members {
+		// 多个节点同时Claim时，只有成功移除的节点处理这条消息
+		removed, err := r.cli.ZRem(KeyAckDue, member).Result()
+		if err != nil {
+			return acks, err
+		}
+		if removed == 0 {
+			continue
+		}
+		app, account, messageId, ok := parseAckMember(member)
+		if !ok {
+			continue
+		}
+		val, err := r.cli.Get(KeyAckPending(app, account, messageId)).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return acks, err
+		}
+		ack, err := decodeAck(val)
+		if err != nil {
+			return acks, err
+		}
+		acks = append(acks, ack)
+	}
+	return acks, nil
+}
+
+func (r *RedisAckStorage) Schedule(ack *kingim.PendingAck, due int64) error {
+	bts, err := json.Marshal(ack)
+	if err != nil {
+		return err
+	}
+	keys := []string{KeyAckPending(ack.App, ack.Account, ack.MessageId), KeyAckDue}
+	return scheduleAckScript.Run(r.cli, keys, bts, AckExpired.Milliseconds(), due, ackMember(ack.App, ack.Account, ack.MessageId)).Err()
+}
+
+func (r *RedisAckStorage) Len() (int, error) {
+	count, err := r.cli.ZCard(KeyAckDue).Result()
+	return int(count), err
+}
+
+func decodeAck(val string) (*kingim.PendingAck, error) {
+	var ack kingim.PendingAck
+	if err := json.Unmarshal([]byte(val), &ack); err != nil {
+		return nil, err
+	}
+	return &ack, nil
+}
+
+// ackMember 账号中可能包含冒号，因此messageId和app放在前面
+func ackMember(app string, account string, messageId int64) string {
+	return fmt.Sprintf("%d:%s", messageId, receiverMember(app, account))
+}
+
+func parseAckMember(member string) (string, string, int64, bool) {
+	arr := strings.SplitN(member, ":", 2)
+	if len(arr) != 2 {
+		return "", "", 0, false
+	}
+	messageId, err := strconv.ParseInt(arr[0], 10, 64)
+	if err != nil {
+		return "", "", 0, false
+	}
+	app, account, ok := parseReceiverMember(arr[1])
+	return app, account, messageId, ok
+}
+
+// receiverMember app中不包含冒号，账号中可能包含
+func receiverMember(app string, account string) string {
+	return fmt.Sprintf("%s:%s", app, account)
+}
+
+func parseReceiverMember(member string) (string, string, bool) {
+	arr := strings.SplitN(member, ":", 2)
+	if len(arr) != 2 {
+		return "", "", false
+	}
+	return arr[0], arr[1], true
+}
+
+// KeyAckPending app中账号等待ack的消息
+func KeyAckPending(app string, account string, messageId int64) string {
+	return fmt.Sprintf("ack:pending:%s:%s:%d", app, account, messageId)
+}
+
+// KeyAckMessage 消息等待ack的账号，成员为app:account，撤回时使用
+func KeyAckMessage(messageId int64) string {
+	return fmt.Sprintf("ack:msg:%d", messageId)
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v7"
+	"github.com/stretchr/testify/assert"
+	"kingim"
+)
+
+func testAckStorage(t *testing.T, s kingim.AckStorage) {
+	added, err := s.Add(&kingim.PendingAck{App: "app1", Account: "test2", MessageId: 1, Sender: "test1", Push: []byte("hello")}, 100)
+	assert.Nil(t, err)
+	assert.True(t, added)
+	// 重复的Add不会重置重试次数
+	added, err = s.Add(&kingim.PendingAck{App: "app1", Account: "test2", MessageId: 1, Sender: "test1", Retries: 0}, 300)
+	assert.Nil(t, err)
+	assert.False(t, added)
+	_, _ = s.Add(&kingim.PendingAck{App: "app1", Account: "test3", MessageId: 1, Sender: "test1"}, 200)
+	_, _ = s.Add(&kingim.PendingAck{App: "app1", Account: "test3", MessageId: 2, Sender: "test1"}, 200)
+	count, err := s.Len()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	acks, err := s.Claim(50, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, acks)
+
+	acks, err = s.Claim(150, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(acks))
+	assert.Equal(t, "test2", acks[0].Account)
+	assert.Equal(t, []byte("hello"), acks[0].Push)
+	// 已经被取走的消息不会再次返回
+	claimed, err := s.Claim(150, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, claimed)
+
+	acks[0].Retries++
+	assert.Nil(t, s.Schedule(acks[0], 400))
+	count, _ = s.Len()
+	assert.Equal(t, 3, count)
+
+	// 被取走之后仍然可以被确认
+	acks, err = s.Claim(250, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(acks))
+	ack, err := s.Remove("app1", "test3", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "test1", ack.Sender)
+	// 已经被确认的消息不能重新放回
+	assert.Nil(t, s.Schedule(&kingim.PendingAck{App: "app1", Account: "test3", MessageId: 2}, 500))
+	ack, err = s.Remove("app1", "test3", 2)
+	assert.Nil(t, err)
+	assert.Nil(t, ack)
+
+	// 重新放回之后保留重试次数
+	acks, err = s.Claim(450, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(acks))
+	assert.Equal(t, 1, acks[0].Retries)
+	assert.Nil(t, s.Schedule(acks[0], 500))
+
+	assert.Nil(t, s.RemoveMessage(1))
+	count, _ = s.Len()
+	assert.Equal(t, 0, count)
+	acks, err = s.Claim(1000, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, acks)
+	ack, err = s.Remove("app1", "test2", 1)
+	assert.Nil(t, err)
+	assert.Nil(t, ack)
+
+	// 不同app中同名的账号互不影响
+	_, _ = s.Add(&kingim.PendingAck{App: "app1", Account: "test:2", MessageId: 3, Sender: "test1"}, 600)
+	added, err = s.Add(&kingim.PendingAck{App: "app2", Account: "test:2", MessageId: 3, Sender: "test4"}, 600)
+	assert.Nil(t, err)
+	assert.True(t, added)
+	ack, err = s.Remove("app2", "test:2", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, "test4", ack.Sender)
+	acks, err = s.Claim(1000, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(acks))
+	assert.Equal(t, "app1", acks[0].App)
+	assert.Equal(t, "test:2", acks[0].Account)
+	assert.Nil(t, s.RemoveMessage(3))
+	ack, err = s.Remove("app1", "test:2", 3)
+	assert.Nil(t, err)
+	assert.Nil(t, ack)
+}
+
+func TestMemoryAckStorage(t *testing.T) {
+	testAckStorage(t, NewMemoryAckStorage())
+}
+
+func TestRedisAckStorage(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	cli := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer cli.Close()
+	testAckStorage(t, NewRedisAckStorage(cli))
+}
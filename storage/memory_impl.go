@@ -0,0 +1,279 @@
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"kingim"
+	"kingim/wire/pkt"
+)
+
+type sessionItem struct {
+	session  *pkt.Session
+	expireAt time.Time
+}
+
+type locationItem struct {
+	devices  map[string]kingim.Location
+	expireAt time.Time
+}
+
+// MemoryStorage 基于内存的SessionStorage，用于单节点部署和测试，
+// 过期规则与RedisStorage一致：每次Add和Refresh都会刷新账号的过期时间，每个设备另外按自己的过期时间过期
+type MemoryStorage struct {
+	sync.RWMutex
+	ttl       time.Duration
+	sessions  map[string]*sessionItem
+	locations map[string]*locationItem
+	gates     map[string]map[string]struct{}
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStorage 创建MemoryStorage，ttl小于等于0时使用LocationExpired
+func NewMemoryStorage(ttl time.Duration) kingim.SessionStorage {
+	return newMemoryStorage(ttl)
+}
+
+func newMemoryStorage(ttl time.Duration) *MemoryStorage {
+	if ttl <= 0 {
+		ttl = LocationExpired
+	}
+	return &MemoryStorage{
+		ttl:       ttl,
+		sessions:  make(map[string]*sessionItem),
+		locations: make(map[string]*locationItem),
+		gates:     make(map[string]map[string]struct{}),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (m *MemoryStorage) Add(session *pkt.Session) error {
+	m.Lock()
+	defer m.Unlock()
+	now := m.now()
+	m.sweep(now)
+	expireAt := now.Add(m.ttl)
+
+	item, ok := m.locations[session.Account]
+	if !ok || !now.Before(item.expireAt) {
+		item = &locationItem{devices: make(map[string]kingim.Location)}
+		m.locations[session.Account] = item
+	}
+	item.devices[session.Device] = kingim.Location{
+		ChannelId: session.ChannelId,
+		GateId:    session.GateId,
+		Device:    session.Device,
+		LoginAt:   millis(now),
+		ExpireAt:  millis(expireAt),
+		App:       session.App,
+		Account:   session.Account,
+	}
+	item.expireAt = expireAt
+
+	m.sessions[session.ChannelId] = &sessionItem{
+		session:  proto.Clone(session).(*pkt.Session),
+		expireAt: expireAt,
+	}
+	if _, ok := m.gates[session.GateId]; !ok {
+		m.gates[session.GateId] = make(map[string]struct{})
+	}
+	m.gates[session.GateId][session.ChannelId] = struct{}{}
+	return nil
+}
+
+func (m *MemoryStorage) Delete(account string, channelId string) error {
+	m.Lock()
+	defer m.Unlock()
+	m.delete(channelId)
+	return nil
+}
+
+func (m *MemoryStorage) DeleteByGate(gateId string) (int, error) {
+	m.Lock()
+	defer m.Unlock()
+	var count int
+	for channelId := range m.gates[gateId] {
+		if m.delete(channelId) {
+			count++
+		}
+	}
+	delete(m.gates, gateId)
+	return count, nil
+}
+
+func (m *MemoryStorage) GetByGate(gateId string) ([]*pkt.Session, error) {
+	m.RLock()
+	defer m.RUnlock()
+	now := m.now()
+	sessions := make([]*pkt.Session, 0, len(m.gates[gateId]))
+	for channelId := range m.gates[gateId] {
+		sn, ok := m.sessions[channelId]
+		if !ok || !now.Before(sn.expireAt) {
+			continue
+		}
+		sessions = append(sessions, proto.Clone(sn.session).(*pkt.Session))
+	}
+	return sessions, nil
+}
+
+func (m *MemoryStorage) GetByTags(app string, tags ...string) ([]*pkt.Session, error) {
+	m.RLock()
+	defer m.RUnlock()
+	now := m.now()
+	var sessions []*pkt.Session
+	for _, sn := range m.sessions {
+		if sn.session.App != app || !now.Before(sn.expireAt) || !hasAnyTag(sn.session.Tags, tags) {
+			continue
+		}
+		sessions = append(sessions, proto.Clone(sn.session).(*pkt.Session))
+	}
+	return sessions, nil
+}
+
+func hasAnyTag(tags []string, wants []string) bool {
+	for _, tag := range tags {
+		for _, want := range wants {
+			if tag == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// delete 删除会话及其位置信息，调用方需要持有写锁
+func (m *MemoryStorage) delete(channelId string) bool {
+	sn, ok := m.sessions[channelId]
+	if !ok {
+		return false
+	}
+	delete(m.sessions, channelId)
+	if channels, ok := m.gates[sn.session.GateId]; ok {
+		delete(channels, channelId)
+		if len(channels) == 0 {
+			delete(m.gates, sn.session.GateId)
+		}
+	}
+	account := sn.session.Account
+	if item, ok := m.locations[account]; ok {
+		// 同一设备重新登录之后，旧连接的登出不能删掉新的位置信息
+		if loc, ok := item.devices[sn.session.Device]; ok && loc.ChannelId == channelId {
+			delete(item.devices, sn.session.Device)
+		}
+		if len(item.devices) == 0 {
+			delete(m.locations, account)
+		}
+	}
+	return true
+}
+
+func (m *MemoryStorage) Get(channelId string) (*pkt.Session, error) {
+	m.RLock()
+	defer m.RUnlock()
+	sn, ok := m.sessions[channelId]
+	if !ok || !m.now().Before(sn.expireAt) {
+		return nil, kingim.ErrSessionNil
+	}
+	return proto.Clone(sn.session).(*pkt.Session), nil
+}
+
+func (m *MemoryStorage) Refresh(gateId string, channelId string) error {
+	m.Lock()
+	defer m.Unlock()
+	now := m.now()
+	sn, ok := m.sessions[channelId]
+	if !ok || !now.Before(sn.expireAt) || sn.session.GateId != gateId {
+		return kingim.ErrSessionNil
+	}
+	sn.expireAt = now.Add(m.ttl)
+	if item, ok := m.locations[sn.session.Account]; ok && now.Before(item.expireAt) {
+		item.expireAt = sn.expireAt
+		// 只延长这个设备的过期时间
+		if loc, ok := item.devices[sn.session.Device]; ok && loc.ChannelId == channelId {
+			loc.ExpireAt = millis(sn.expireAt)
+			item.devices[sn.session.Device] = loc
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStorage) GetLocations(accounts ...string) ([]*kingim.Location, error) {
+	m.RLock()
+	defer m.RUnlock()
+	now := m.now()
+	var result = make([]*kingim.Location, 0)
+	for _, account := range accounts {
+		item, ok := m.locations[account]
+		if !ok || !now.Before(item.expireAt) {
+			continue
+		}
+		for _, loc := range item.devices {
+			loc := loc
+			if loc.Expired(millis(now)) {
+				continue
+			}
+			result = append(result, &loc)
+		}
+	}
+	if len(result) == 0 {
+		return nil, kingim.ErrSessionNil
+	}
+	return result, nil
+}
+
+func (m *MemoryStorage) GetLocation(account string, device string) (*kingim.Location, error) {
+	m.RLock()
+	defer m.RUnlock()
+	now := m.now()
+	item, ok := m.locations[account]
+	if !ok || !now.Before(item.expireAt) {
+		return nil, kingim.ErrSessionNil
+	}
+	if device != "" {
+		loc, ok := item.devices[device]
+		if !ok || loc.Expired(millis(now)) {
+			return nil, kingim.ErrSessionNil
+		}
+		return &loc, nil
+	}
+	// 没有指定设备时返回最近登录的设备
+	var latest *kingim.Location
+	for _, loc := range item.devices {
+		loc := loc
+		if loc.Expired(millis(now)) {
+			continue
+		}
+		if latest == nil || loc.LoginAt > latest.LoginAt {
+			latest = &loc
+		}
+	}
+	if latest == nil {
+		return nil, kingim.ErrSessionNil
+	}
+	return latest, nil
+}
+
+// sweep 每过一个ttl清理一次过期的数据，调用方需要持有写锁
+func (m *MemoryStorage) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < m.ttl {
+		return
+	}
+	m.lastSweep = now
+	for id, sn := range m.sessions {
+		if !now.Before(sn.expireAt) {
+			m.delete(id)
+		}
+	}
+	for account, item := range m.locations {
+		if !now.Before(item.expireAt) {
+			delete(m.locations, account)
+		}
+	}
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"sync"
+
+	"kingim"
+)
+
+type presenceKey struct {
+	app     string
+	account string
+}
+
+// MemoryPresenceStorage 基于内存的PresenceStorage，用于单节点部署和测试，订阅关系不会过期
+type MemoryPresenceStorage struct {
+	sync.RWMutex
+	presences   map[presenceKey]kingim.Presence
+	subscribers map[presenceKey]map[string]struct{}
+	// online 在线账号的检查时间
+	online map[presenceKey]int64
+}
+
+func NewMemoryPresenceStorage() kingim.PresenceStorage {
+	return &MemoryPresenceStorage{
+		presences:   make(map[presenceKey]kingim.Presence),
+		subscribers: make(map[presenceKey]map[string]struct{}),
+		online:      make(map[presenceKey]int64),
+	}
+}
+
+func (m *MemoryPresenceStorage) SetStatus(app string, account string, status string) error {
+	m.Lock()
+	defer m.Unlock()
+	key := presenceKey{app: app, account: account}
+	p := m.presences[key]
+	p.Account = account
+	p.Status = status
+	m.presences[key] = p
+	return nil
+}
+
+func (m *MemoryPresenceStorage) SetLastSeen(app string, account string, lastSeen int64) error {
+	m.Lock()
+	defer m.Unlock()
+	key := presenceKey{app: app, account: account}
+	p := m.presences[key]
+	p.Account = account
+	p.LastSeen = lastSeen
+	m.presences[key] = p
+	return nil
+}
+
+func (m *MemoryPresenceStorage) GetPresences(app string, accounts ...string) ([]*kingim.Presence, error) {
+	m.RLock()
+	defer m.RUnlock()
+	presences := make([]*kingim.Presence, len(accounts))
+	for i, account := range accounts {
+		p := m.presences[presenceKey{app: app, account: account}]
+		p.Account = account
+		presences[i] = &p
+	}
+	return presences, nil
+}
+
+func (m *MemoryPresenceStorage) Subscribe(app string, subscriber string, accounts ...string) error {
+	m.Lock()
+	defer m.Unlock()
+	for _, account := range accounts {
+		key := presenceKey{app: app, account: account}
+		subs, ok := m.subscribers[key]
+		if !ok {
+			subs = make(map[string]struct{})
+			m.subscribers[key] = subs
+		}
+		subs[subscriber] = struct{}{}
+	}
+	return nil
+}
+
+func (m *MemoryPresenceStorage) Unsubscribe(app string, subscriber string, accounts ...string) error {
+	m.Lock()
+	defer m.Unlock()
+	for _, account := range accounts {
+		key := presenceKey{app: app, account: account}
+		delete(m.subscribers[key], subscriber)
+		if len(m.subscribers[key]) == 0 {
+			delete(m.subscribers, key)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryPresenceStorage) Subscribers(app string, account string) ([]string, error) {
+	m.RLock()
+	defer m.RUnlock()
+	key := presenceKey{app: app, account: account}
+	subs := make([]string, 0, len(m.subscribers[key]))
+	for sub := range m.subscribers[key] {
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+func (m *MemoryPresenceStorage) SetOnline(app string, account string, checkAt int64) error {
+	m.Lock()
+	defer m.Unlock()
+	m.online[presenceKey{app: app, account: account}] = checkAt
+	return nil
+}
+
+func (m *MemoryPresenceStorage) RemoveOnline(app string, account string) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.online, presenceKey{app: app, account: account})
+	return nil
+}
+
+func (m *MemoryPresenceStorage) ClaimExpired(now int64, count int) ([]*kingim.OnlineAccount, error) {
+	m.Lock()
+	defer m.Unlock()
+	var accounts []*kingim.OnlineAccount
+	for key, checkAt := range m.online {
+		if len(accounts) >= count {
+			break
+		}
+		if checkAt > now {
+			continue
+		}
+		delete(m.online, key)
+		accounts = append(accounts, &kingim.OnlineAccount{App: key.app, Account: key.account})
+	}
+	return accounts, nil
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+	"kingim"
+)
+
+const (
+	// PresenceExpired 状态和最后在线时间的保存时间
+	PresenceExpired = time.Hour * 24 * 30
+	// SubscriptionExpired 订阅关系的过期时间，客户端每次登录之后需要重新订阅
+	SubscriptionExpired = time.Hour * 24
+)
+
+// KeyPresenceOnline 按检查时间排序的在线账号
+const KeyPresenceOnline = "login:presence:online"
+
+type RedisPresenceStorage struct {
+	cli *redis.Client
+}
+
+func NewRedisPresenceStorage(cli *redis.Client) kingim.PresenceStorage {
+	return &RedisPresenceStorage{cli: cli}
+}
+
+func (r *RedisPresenceStorage) SetStatus(app string, account string, status string) error {
+	return r.set(app, account, "status", status)
+}
+
+func (r *RedisPresenceStorage) SetLastSeen(app string, account string, lastSeen int64) error {
+	return r.set(app, account, "last_seen", lastSeen)
+}
+
+func (r *RedisPresenceStorage) set(app, account, field string, value interface{}) error {
+	key := KeyPresence(app, account)
+	pipe := r.cli.TxPipeline()
+	pipe.HSet(key, field, value)
+	pipe.Expire(key, PresenceExpired)
+	_, err := pipe.Exec()
+	return err
+}
+
+func (r *RedisPresenceStorage) GetPresences(app string, accounts ...string) ([]*kingim.Presence, error) {
+	pipe := r.cli.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, len(accounts))
+	for i, account := range accounts {
+		cmds[i] = pipe.HGetAll(KeyPresence(app, account))
+	}
+	if _, err := pipe.Exec(); err != nil && err != redis.Nil {
+		return nil, err
+	}
+	presences := make([]*kingim.Presence, len(accounts))
+	for i, cmd := range cmds {
+		values := cmd.Val()
+		lastSeen, _ := strconv.ParseInt(values["last_seen"], 10, 64)
+		presences[i] = &kingim.Presence{
+			Account:  accounts[i],
+			Status:   values["status"],
+			LastSeen: lastSeen,
+		}
+	}
+	return presences, nil
+}
+
+func (r *RedisPresenceStorage) Subscribe(app string, subscriber string, accounts ...string) error {
+	pipe := r.cli.TxPipeline()
+	for _, account := range accounts {
+		key := KeySubscribers(app, account)
+		pipe.SAdd(key, subscriber)
+		pipe.Expire(key, SubscriptionExpired)
+	}
+	_, err := pipe.Exec()
+	return err
+}
+
+func (r *RedisPresenceStorage) Unsubscribe(app string, subscriber string, accounts ...string) error {
+	pipe := r.cli.TxPipeline()
+	for _, account := range accounts {
+		pipe.SRem(KeySubscribers(app, account), subscriber)
+	}
+	_, err := pipe.Exec()
+	return err
+}
+
+func (r *RedisPresenceStorage) Subscribers(app string, account string) ([]string, error) {
+	return r.cli.SMembers(KeySubscribers(app, account)).Result()
+}
+
+func (r *RedisPresenceStorage) SetOnline(app string, account string, checkAt int64) error {
+	return r.cli.ZAdd(KeyPresenceOnline, &redis.Z{Score: float64(checkAt), Member: onlineMember(app, account)}).Err()
+}
+
+func (r *RedisPresenceStorage) RemoveOnline(app string, account string) error {
+	return r.cli.ZRem(KeyPresenceOnline, onlineMember(app, account)).Err()
+}
+
+func (r *RedisPresenceStorage) ClaimExpired(now int64, count int) ([]*kingim.OnlineAccount, error) {
+	members, err := r.cli.ZRangeByScore(KeyPresenceOnline, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now, 10),
+		Count: int64(count),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]*kingim.OnlineAccount, 0, len(members))
+	for _, member := range members {
+		// 多个节点同时检查时，只有成功移除的节点处理这个账号
+		removed, err := r.cli.ZRem(KeyPresenceOnline, member).Result()
+		if err != nil {
+			return accounts, err
+		}
+		if removed == 0 {
+			continue
+		}
+		arr := strings.SplitN(member, ":", 2)
+		if len(arr) != 2 {
+			continue
+		}
+		accounts = append(accounts, &kingim.OnlineAccount{App: arr[0], Account: arr[1]})
+	}
+	return accounts, nil
+}
+
+// onlineMember app中不包含冒号，账号中可能包含
+func onlineMember(app string, account string) string {
+	return fmt.Sprintf("%s:%s", app, account)
+}
+
+// KeyPresence app中账号的状态
+func KeyPresence(app string, account string) string {
+	return fmt.Sprintf("login:presence:%s:%s", app, account)
+}
+
+// KeySubscribers app中订阅了这个账号状态的账号
+func KeySubscribers(app string, account string) string {
+	return fmt.Sprintf("login:subs:%s:%s", app, account)
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v7"
+	"github.com/stretchr/testify/assert"
+	"kingim"
+)
+
+func testPresenceStorage(t *testing.T, s kingim.PresenceStorage) {
+	assert.Nil(t, s.SetStatus("app1", "test1", "busy"))
+	assert.Nil(t, s.SetLastSeen("app1", "test1", 1000))
+	assert.Nil(t, s.SetLastSeen("app1", "test2", 2000))
+
+	presences, err := s.GetPresences("app1", "test1", "test2", "test3")
+	assert.Nil(t, err)
+	assert.Equal(t, []*kingim.Presence{
+		{Account: "test1", Status: "busy", LastSeen: 1000},
+		{Account: "test2", LastSeen: 2000},
+		{Account: "test3"},
+	}, presences)
+	// 其它app中的同名账号
+	presences, err = s.GetPresences("app2", "test1")
+	assert.Nil(t, err)
+	assert.Equal(t, []*kingim.Presence{{Account: "test1"}}, presences)
+
+	assert.Nil(t, s.Subscribe("app1", "test2", "test1", "test3"))
+	assert.Nil(t, s.Subscribe("app1", "test3", "test1"))
+	assert.Nil(t, s.Subscribe("app2", "test4", "test1"))
+	subs, err := s.Subscribers("app1", "test1")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"test2", "test3"}, subs)
+
+	assert.Nil(t, s.Unsubscribe("app1", "test2", "test1"))
+	subs, err = s.Subscribers("app1", "test1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"test3"}, subs)
+	subs, err = s.Subscribers("app2", "test1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"test4"}, subs)
+
+	subs, err = s.Subscribers("app1", "test4")
+	assert.Nil(t, err)
+	assert.Empty(t, subs)
+
+	// 到了检查时间的在线账号只被取出一次
+	assert.Nil(t, s.SetOnline("app1", "test1", 100))
+	assert.Nil(t, s.SetOnline("app2", "test1", 200))
+	assert.Nil(t, s.SetOnline("app1", "test:2", 150))
+	assert.Nil(t, s.SetOnline("app1", "test3", 150))
+	assert.Nil(t, s.RemoveOnline("app1", "test3"))
+	accounts, err := s.ClaimExpired(150, 10)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []*kingim.OnlineAccount{
+		{App: "app1", Account: "test1"},
+		{App: "app1", Account: "test:2"},
+	}, accounts)
+	accounts, err = s.ClaimExpired(150, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, accounts)
+	accounts, err = s.ClaimExpired(300, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []*kingim.OnlineAccount{{App: "app2", Account: "test1"}}, accounts)
+}
+
+func TestMemoryPresenceStorage(t *testing.T) {
+	testPresenceStorage(t, NewMemoryPresenceStorage())
+}
+
+func TestRedisPresenceStorage(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	cli := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer cli.Close()
+	testPresenceStorage(t, NewRedisPresenceStorage(cli))
+}
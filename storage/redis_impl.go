@@ -10,55 +10,208 @@ import (
 )
 
 const (
-	LocationExpired = time.Hour*48
+	// LocationExpired 默认的会话过期时间，在线的连接由网关定时调用Refresh续期
+	LocationExpired = time.Minute*5
+	// gateBatchSize GetByGate 每次读取的会话数
+	gateBatchSize = 500
 )
+// RedisStorage 账号所有设备的位置信息保存在同一个hash中，key的ttl由任意一个设备的续期刷新，
+// 因此每个设备的位置信息中另外保存自己的过期时间，读取时跳过已经过期的设备
 type RedisStorage struct {
 	cli *redis.Client
+	ttl time.Duration
+	now func() time.Time
 }
 
-func NewRedisStorage(cli*redis.Client) kingim.SessionStorage {
+// NewRedisStorage ttl小于等于0时使用LocationExpired
+func NewRedisStorage(cli*redis.Client, ttl time.Duration) kingim.SessionStorage {
+	return newRedisStorage(cli, ttl)
+}
+
+func newRedisStorage(cli*redis.Client, ttl time.Duration) *RedisStorage {
+	if ttl <= 0 {
+		ttl = LocationExpired
+	}
 	return &RedisStorage{
 		cli: cli,
+		ttl: ttl,
+		now: time.Now,
 	}
 }
+
+func (r*RedisStorage) millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
 func (r*RedisStorage) Add(session *pkt.Session) error {
 	loc := kingim.Location{
 		ChannelId: session.ChannelId,
 		GateId: session.GateId,
+		Device: session.Device,
+		LoginAt: r.millis(r.now()),
+		ExpireAt: r.millis(r.now().Add(r.ttl)),
+		App: session.App,
 	}
-	locKey := KeyLocation(session.Account, session.Device)
-	err := r.cli.Set(locKey, loc.Bytes(), LocationExpired).Err()
-	if err != nil {
-		return err
-	}
+	// 一个账号的所有设备保存在同一个hash中，field是设备
+	locKey := KeyLocation(session.Account)
+	pipe := r.cli.TxPipeline()
+	pipe.HSet(locKey, session.Device, loc.Bytes())
+	pipe.Expire(locKey, r.ttl)
 	SesKey := KeySession(session.ChannelId)
 	buf,_ := proto.Marshal(session)
-	err = r.cli.Set(SesKey, buf,LocationExpired).Err()
-	if err!= nil {
+	pipe.Set(SesKey, buf,r.ttl)
+	// 网关上的channel索引，网关宕机时用于批量清理
+	gateKey := KeyGate(session.GateId)
+	pipe.SAdd(gateKey, session.ChannelId)
+	pipe.Expire(gateKey, r.ttl)
+	// 标签的channel索引，按标签推送时使用
+	for _, tag := range session.Tags {
+		tagKey := KeyTag(session.App, tag)
+		pipe.SAdd(tagKey, session.ChannelId)
+		pipe.Expire(tagKey, r.ttl)
+	}
+	_, err := pipe.Exec()
+	if err != nil {
 		return err
 	}
 	return nil
 }
 
 func (r*RedisStorage) Delete(account string, channelId string) error {
-	lockey := KeyLocation(account, "")
-	err := r.cli.Del(lockey).Err()
+	session, err := r.Get(channelId)
+	if err == kingim.ErrSessionNil {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
-	snKey := KeySession(channelId)
-	err = r.cli.Del(snKey).Err()
+	return r.delete(session)
+}
+
+func (r*RedisStorage) delete(session *pkt.Session) error {
+	// 只有当设备上的位置信息还属于这个channel时才删除，
+	// 避免同一设备重新登录之后，旧连接的登出把新的位置信息删掉
+	locKey := KeyLocation(session.Account)
+	err := r.cli.Watch(func(tx *redis.Tx) error {
+		bts, err := tx.HGet(locKey, session.Device).Bytes()
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var loc kingim.Location
+		if err = loc.Unmarshal(bts); err == nil && loc.ChannelId != session.ChannelId {
+			return nil
+		}
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.HDel(locKey, session.Device)
+			return nil
+		})
+		return err
+	}, locKey)
 	if err != nil {
 		return err
 	}
-	return nil
+	pipe := r.cli.Pipeline()
+	pipe.Del(KeySession(session.ChannelId))
+	pipe.SRem(KeyGate(session.GateId), session.ChannelId)
+	for _, tag := range session.Tags {
+		pipe.SRem(KeyTag(session.App, tag), session.ChannelId)
+	}
+	_, err = pipe.Exec()
+	return err
+}
+
+func (r*RedisStorage) DeleteByGate(gateId string) (int, error) {
+	sessions, err := r.GetByGate(gateId)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	for _, session := range sessions {
+		if err := r.delete(session); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, r.cli.Del(KeyGate(gateId)).Err()
+}
+
+func (r*RedisStorage) GetByGate(gateId string) ([]*pkt.Session, error) {
+	channels, err := r.cli.SMembers(KeyGate(gateId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	sessions, _, err := r.getSessions(channels)
+	return sessions, err
+}
+
+func (r*RedisStorage) GetByTags(app string, tags ...string) ([]*pkt.Session, error) {
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = KeyTag(app, tag)
+	}
+	channels, err := r.cli.SUnion(keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	sessions, missing, err := r.getSessions(channels)
+	if err != nil {
+		return nil, err
+	}
+	// 没有登出就过期的会话不会从索引中删除，读取时顺便清理，清理失败不影响结果
+	if len(missing) > 0 {
+		members := make([]interface{}, len(missing))
+		for i, id := range missing {
+			members[i] = id
+		}
+		pipe := r.cli.Pipeline()
+		for _, key := range keys {
+			pipe.SRem(key, members...)
+		}
+		_, _ = pipe.Exec()
+	}
+	return sessions, nil
+}
+
+// getSessions 批量读取会话，同时返回已经过期或者登出的channel
+func (r*RedisStorage) getSessions(channels []string) ([]*pkt.Session, []string, error) {
+	var missing []string
+	sessions := make([]*pkt.Session, 0, len(channels))
+	for i := 0; i < len(channels); i += gateBatchSize {
+		end := i + gateBatchSize
+		if end > len(channels) {
+			end = len(channels)
+		}
+		keys := make([]string, 0, end-i)
+		for _, id := range channels[i:end] {
+			keys = append(keys, KeySession(id))
+		}
+		list, err := r.cli.MGet(keys...).Result()
+		if err != nil {
+			return nil, nil, err
+		}
+		// 已经过期或者登出的会话不在结果中
+		for j, val := range list {
+			if val == nil {
+				missing = append(missing, channels[i+j])
+				continue
+			}
+			var session pkt.Session
+			if err := proto.Unmarshal([]byte(val.(string)), &session); err != nil {
+				continue
+			}
+			sessions = append(sessions, &session)
+		}
+	}
+	return sessions, missing, nil
 }
 
 func (r*RedisStorage) Get(channelId string) (*pkt.Session, error) {
 	snKey := KeySession(channelId)
 	bts,err := r.cli.Get(snKey).Bytes()
 	if err != nil {
-		if err != redis.Nil {
+		if err == redis.Nil {
 			return nil,kingim.ErrSessionNil
 		}
 		return nil,err
@@ -68,34 +221,110 @@ func (r*RedisStorage) Get(channelId string) (*pkt.Session, error) {
 	return &session,nil
 }
 
+func (r*RedisStorage) Refresh(gateId string, channelId string) error {
+	session, err := r.Get(channelId)
+	if err != nil {
+		return err
+	}
+	// 网关只能为自己的连接续期
+	if session.GateId != gateId {
+		return kingim.ErrSessionNil
+	}
+	// 只延长这个设备的过期时间，位置信息已经属于同一设备上新的channel时不修改
+	locKey := KeyLocation(session.Account)
+	err = r.cli.Watch(func(tx *redis.Tx) error {
+		bts, err := tx.HGet(locKey, session.Device).Bytes()
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var loc kingim.Location
+		if err = loc.Unmarshal(bts); err != nil || loc.ChannelId != channelId {
+			return nil
+		}
+		loc.ExpireAt = r.millis(r.now().Add(r.ttl))
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.HSet(locKey, session.Device, loc.Bytes())
+			return nil
+		})
+		return err
+	}, locKey)
+	if err != nil {
+		return err
+	}
+	pipe := r.cli.Pipeline()
+	pipe.Expire(KeySession(channelId), r.ttl)
+	pipe.Expire(locKey, r.ttl)
+	pipe.Expire(KeyGate(session.GateId), r.ttl)
+	for _, tag := range session.Tags {
+		pipe.Expire(KeyTag(session.App, tag), r.ttl)
+	}
+	_, err = pipe.Exec()
+	return err
+}
+
 func (r*RedisStorage) GetLocation(account string, device string) (*kingim.Location, error) {
-	LocKey := KeyLocation(account,"")
-	bts,err := r.cli.Get(LocKey).Bytes()
+	LocKey := KeyLocation(account)
+	if device == "" {
+		// 没有指定设备时返回最近登录的设备
+		all, err := r.cli.HGetAll(LocKey).Result()
+		if err != nil {
+			return nil, err
+		}
+		now := r.millis(r.now())
+		var latest *kingim.Location
+		for _, val := range all {
+			loc := kingim.Location{Account: account}
+			if err := loc.Unmarshal([]byte(val)); err != nil || loc.Expired(now) {
+				continue
+			}
+			if latest == nil || loc.LoginAt > latest.LoginAt {
+				latest = &loc
+			}
+		}
+		if latest == nil {
+			return nil, kingim.ErrSessionNil
+		}
+		return latest, nil
+	}
+	bts,err := r.cli.HGet(LocKey, device).Bytes()
 	if err != nil {
-		if err != redis.Nil {
+		if err == redis.Nil {
 			return nil,kingim.ErrSessionNil
 		}
 		return nil,err
 	}
-	var loc kingim.Location
+	loc := kingim.Location{Account: account}
 	_ = loc.Unmarshal(bts)
+	if loc.Expired(r.millis(r.now())) {
+		return nil, kingim.ErrSessionNil
+	}
 	return &loc, nil
 }
 
 func (r*RedisStorage) GetLocations(account ...string) ([]*kingim.Location, error) {
-	Keys := KeyLocations(account...)
-	list,err := r.cli.MGet(Keys...).Result()
+	pipe := r.cli.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, len(account))
+	for i, key := range KeyLocations(account...) {
+		cmds[i] = pipe.HGetAll(key)
+	}
+	_, err := pipe.Exec()
 	if err != nil {
 		return nil,err
 	}
+	now := r.millis(r.now())
 	var result = make([]*kingim.Location,0)
-	for _,l := range list {
-		if l == nil {
-			continue
+	for i,cmd := range cmds {
+		for _, val := range cmd.Val() {
+			loc := kingim.Location{Account: account[i]}
+			// 崩溃之后没有登出的设备，不会因为同一账号其它设备的续期而一直在线
+			if err := loc.Unmarshal([]byte(val)); err != nil || loc.Expired(now) {
+				continue
+			}
+			result = append(result, &loc)
 		}
-		var loc kingim.Location
-		_ = loc.Unmarshal([]byte(l.(string)))
-		result = append(result, &loc)
 	}
 	if len(result) == 0 {
 		return nil,kingim.ErrSessionNil
@@ -107,19 +336,25 @@ func KeySession(channel string) string {
 	return fmt.Sprintf("login:sn:%s", channel)
 }
 
+// KeyLocation 账号所有设备的位置信息
+func KeyLocation(account string) string {
+	return fmt.Sprintf("login:locs:%s", account)
+}
 
-func KeyLocation(account,device string) string {
-	if device == "" {
-		return fmt.Sprintf("login:loc:%s",account)
-	}
+// KeyGate 网关上所有channel的索引
+func KeyGate(gateId string) string {
+	return fmt.Sprintf("login:gate:%s", gateId)
+}
 
-	return fmt.Sprintf("login:loc:%s:%s", account, device)
+// KeyTag app中登录时带有这个标签的channel的索引
+func KeyTag(app string, tag string) string {
+	return fmt.Sprintf("login:tag:%s:%s", app, tag)
 }
 
 func KeyLocations(accounts ...string) []string {
 	arr := make([]string, len(accounts))
 	for i, account := range accounts {
-		arr[i] = KeyLocation(account, "")
+		arr[i] = KeyLocation(account)
 	}
 	return arr
 }
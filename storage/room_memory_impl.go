@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"sync"
+
+	"kingim"
+)
+
+// MemoryRoomStorage 基于内存的RoomStorage，用于单节点部署和测试
+type MemoryRoomStorage struct {
+	sync.RWMutex
+	// room -> gateId -> channels
+	rooms map[string]map[string]map[string]struct{}
+	// channel -> rooms
+	joined map[string]map[string]struct{}
+}
+
+func NewMemoryRoomStorage() kingim.RoomStorage {
+	return &MemoryRoomStorage{
+		rooms:  make(map[string]map[string]map[string]struct{}),
+		joined: make(map[string]map[string]struct{}),
+	}
+}
+
+func (m *MemoryRoomStorage) Join(room string, gateId string, channelId string) error {
+	m.Lock()
+	defer m.Unlock()
+	gates, ok := m.rooms[room]
+	if !ok {
+		gates = make(map[string]map[string]struct{})
+		m.rooms[room] = gates
+	}
+	channels, ok := gates[gateId]
+	if !ok {
+		channels = make(map[string]struct{})
+		gates[gateId] = channels
+	}
+	channels[channelId] = struct{}{}
+	rooms, ok := m.joined[channelId]
+	if !ok {
+		rooms = make(map[string]struct{})
+		m.joined[channelId] = rooms
+	}
+	rooms[room] = struct{}{}
+	return nil
+}
+
+func (m *MemoryRoomStorage) Leave(room string, gateId string, channelId string) error {
+	m.Lock()
+	defer m.Unlock()
+	m.leave(room, gateId, channelId)
+	return nil
+}
+
+func (m *MemoryRoomStorage) leave(room string, gateId string, channelId string) {
+	gates := m.rooms[room]
+	delete(gates[gateId], channelId)
+	if len(gates[gateId]) == 0 {
+		delete(gates, gateId)
+	}
+	if len(gates) == 0 {
+		delete(m.rooms, room)
+	}
+	delete(m.joined[channelId], room)
+	if len(m.joined[channelId]) == 0 {
+		delete(m.joined, channelId)
+	}
+}
+
+func (m *MemoryRoomStorage) LeaveAll(gateId string, channelId string) ([]string, error) {
+	m.Lock()
+	defer m.Unlock()
+	rooms := make([]string, 0, len(m.joined[channelId]))
+	for room := range m.joined[channelId] {
+		rooms = append(rooms, room)
+	}
+	for _, room := range rooms {
+		m.leave(room, gateId, channelId)
+	}
+	return rooms, nil
+}
+
+func (m *MemoryRoomStorage) Joined(room string, gateId string, channelId string) (bool, error) {
+	m.RLock()
+	defer m.RUnlock()
+	_, ok := m.rooms[room][gateId][channelId]
+	return ok, nil
+}
+
+func (m *MemoryRoomStorage) Gateways(room string) ([]string, error) {
+	m.RLock()
+	defer m.RUnlock()
+	gates := make([]string, 0, len(m.rooms[room]))
+	for gate := range m.rooms[room] {
+		gates = append(gates, gate)
+	}
+	return gates, nil
+}
+
+// Refresh 内存中的成员不会过期
+func (m *MemoryRoomStorage) Refresh(gateId string, channelIds ...string) error {
+	return nil
+}
+
+func (m *MemoryRoomStorage) DeleteByGate(gateId string) (int, error) {
+	m.Lock()
+	defer m.Unlock()
+	count := 0
+	for room, gates := range m.rooms {
+		channels, ok := gates[gateId]
+		if !ok {
+			continue
+		}
+		count++
+		for channelId := range channels {
+			m.leave(room, gateId, channelId)
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+	"kingim"
+)
+
+// RoomJoinedExpired 房间成员的保存时间，加入时和网关为活跃连接续期时续期。网关宕机时由GatewayWatcher调用DeleteByGate清理，
+// 过期用于清理没有被清理到的数据
+const RoomJoinedExpired = time.Hour * 24
+
+// leaveScript 移除channel，网关在房间中没有成员时从房间的网关列表中移除，保证和Join之间没有竞争
+var leaveScript = redis.NewScript(`
+redis.call('SREM', KEYS[1], ARGV[1])
+if redis.call('SCARD', KEYS[1]) == 0 then
+	redis.call('SREM', KEYS[2], ARGV[2])
+	redis.call('SREM', KEYS[4], ARGV[3])
+end
+redis.call('SREM', KEYS[3], ARGV[3])
+return 0
+`)
+
+type RedisRoomStorage struct {
+	cli *redis.Client
+}
+
+func NewRedisRoomStorage(cli *redis.Client) kingim.RoomStorage {
+	return &RedisRoomStorage{cli: cli}
+}
+
+func (r *RedisRoomStorage) Join(room string, gateId string, channelId string) error {
+	pipe := r.cli.TxPipeline()
+	for key, member := range map[string]string{
+		KeyRoomChannels(room, gateId): channelId,
+		KeyRoomGateways(room):         gateId,
+		KeyRoomJoined(channelId):      room,
+		KeyGateRooms(gateId):          room,
+	} {
+		pipe.SAdd(key, member)
+		pipe.Expire(key, RoomJoinedExpired)
+	}
+	_, err := pipe.Exec()
+	return err
+}
+
+func (r *RedisRoomStorage) Leave(room string, gateId string, channelId string) error {
+	keys := []string{KeyRoomChannels(room, gateId), KeyRoomGateways(room), KeyRoomJoined(channelId), KeyGateRooms(gateId)}
+	return leaveScript.Run(r.cli, keys, channelId, gateId, room).Err()
+}
+
+func (r *RedisRoomStorage) LeaveAll(gateId string, channelId string) ([]string, error) {
+	rooms, err := r.cli.SMembers(KeyRoomJoined(channelId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, room := range rooms {
+		if err = r.Leave(room, gateId, channelId); err != nil {
+			return nil, err
+		}
+	}
+	return rooms, nil
+}
+
+func (r *RedisRoomStorage) Joined(room string, gateId string, channelId string) (bool, error) {
+	return r.cli.SIsMember(KeyRoomChannels(room, gateId), channelId).Result()
+}
+
+func (r *RedisRoomStorage) Gateways(room string) ([]string, error) {
+	return r.cli.SMembers(KeyRoomGateways(room)).Result()
+}
+
+// Refresh 为channels加入的房间续期，网关的续期周期远小于RoomJoinedExpired
+func (r *RedisRoomStorage) Refresh(gateId string, channelIds ...string) error {
+	pipe := r.cli.Pipeline()
+	cmds := make([]*redis.StringSliceCmd, len(channelIds))
+	for i, channelId := range channelIds {
+		cmds[i] = pipe.SMembers(KeyRoomJoined(channelId))
+	}
+	if _, err := pipe.Exec(); err != nil {
+		return err
+	}
+	keys := make(map[string]struct{})
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+		keys[KeyRoomJoined(channelIds[i])] = struct{}{}
+		keys[KeyGateRooms(gateId)] = struct{}{}
+		for _, room := range cmd.Val() {
+			keys[KeyRoomChannels(room, gateId)] = struct{}{}
+			keys[KeyRoomGateways(room)] = struct{}{}
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	pipe = r.cli.Pipeline()
+	for key := range keys {
+		pipe.Expire(key, RoomJoinedExpired)
+	}
+	_, err := pipe.Exec()
+	return err
+}
+
+func (r *RedisRoomStorage) DeleteByGate(gateId string) (int, error) {
+	rooms, err := r.cli.SMembers(KeyGateRooms(gateId)).Result()
+	if err != nil {
+		return 0, err
+	}
+	for _, room := range rooms {
+		channels, err := r.cli.SMembers(KeyRoomChannels(room, gateId)).Result()
+		if err != nil {
+			return 0, err
+		}
+		pipe := r.cli.TxPipeline()
+		for _, channelId := range channels {
+			pipe.SRem(KeyRoomJoined(channelId), room)
+		}
+		pipe.Del(KeyRoomChannels(room, gateId))
+		pipe.SRem(KeyRoomGateways(room), gateId)
+		if _, err = pipe.Exec(); err != nil {
+			return 0, err
+		}
+	}
+	if err = r.cli.Del(KeyGateRooms(gateId)).Err(); err != nil {
+		return 0, err
+	}
+	return len(rooms), nil
+}
+
+// KeyRoomGateways 房间中有成员的网关
+func KeyRoomGateways(room string) string {
+	return fmt.Sprintf("room:gates:%s", room)
+}
+
+// KeyRoomChannels 房间中在gateId上的成员
+func KeyRoomChannels(room string, gateId string) string {
+	return fmt.Sprintf("room:channels:%s:%s", room, gateId)
+}
+
+// KeyRoomJoined channel加入的房间
+func KeyRoomJoined(channelId string) string {
+	return fmt.Sprintf("room:joined:%s", channelId)
+}
+
+// KeyGateRooms 在gateId上有成员的房间，网关宕机时用于清理
+func KeyGateRooms(gateId string) string {
+	return fmt.Sprintf("room:gate:%s", gateId)
+}
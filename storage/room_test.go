@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v7"
+	"github.com/stretchr/testify/assert"
+	"kingim"
+)
+
+func testRoomStorage(t *testing.T, s kingim.RoomStorage) {
+	assert.Nil(t, s.Join("room1", "gate1", "ch1"))
+	assert.Nil(t, s.Join("room1", "gate1", "ch2"))
+	assert.Nil(t, s.Join("room1", "gate2", "ch3"))
+	assert.Nil(t, s.Join("room2", "gate1", "ch1"))
+
+	gates, err := s.Gateways("room1")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"gate1", "gate2"}, gates)
+
+	ok, err := s.Joined("room1", "gate1", "ch2")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// gate2上没有成员之后不再推送到gate2
+	assert.Nil(t, s.Leave("room1", "gate2", "ch3"))
+	gates, err = s.Gateways("room1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gate1"}, gates)
+
+	rooms, err := s.LeaveAll("gate1", "ch1")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"room1", "room2"}, rooms)
+	ok, err = s.Joined("room1", "gate1", "ch1")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	gates, err = s.Gateways("room1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gate1"}, gates)
+	gates, err = s.Gateways("room2")
+	assert.Nil(t, err)
+	assert.Empty(t, gates)
+
+	// gate1 宕机，它在所有房间中的成员都被清理
+	assert.Nil(t, s.Join("room2", "gate1", "ch4"))
+	assert.Nil(t, s.Join("room2", "gate2", "ch5"))
+	count, err := s.DeleteByGate("gate1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	gates, err = s.Gateways("room1")
+	assert.Nil(t, err)
+	assert.Empty(t, gates)
+	gates, err = s.Gateways("room2")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gate2"}, gates)
+	ok, err = s.Joined("room2", "gate1", "ch4")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	rooms, err = s.LeaveAll("gate1", "ch2")
+	assert.Nil(t, err)
+	assert.Empty(t, rooms)
+
+	count, err = s.DeleteByGate("gate1")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestMemoryRoomStorage(t *testing.T) {
+	testRoomStorage(t, NewMemoryRoomStorage())
+}
+
+func TestRedisRoomStorage(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	cli := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer cli.Close()
+	testRoomStorage(t, NewRedisRoomStorage(cli))
+
+	// 没有被清理的成员最终会过期
+	s := NewRedisRoomStorage(cli)
+	assert.Nil(t, s.Join("room3", "gate3", "ch6"))
+	for _, key := range []string{KeyRoomGateways("room3"), KeyRoomChannels("room3", "gate3"), KeyRoomJoined("ch6"), KeyGateRooms("gate3")} {
+		assert.Equal(t, RoomJoinedExpired, mr.TTL(key), key)
+	}
+	// 网关续期时为连接加入的房间续期
+	mr.FastForward(time.Hour)
+	assert.Nil(t, s.Refresh("gate3", "ch6", "ch7"))
+	for _, key := range []string{KeyRoomGateways("room3"), KeyRoomChannels("room3", "gate3"), KeyRoomJoined("ch6"), KeyGateRooms("gate3")} {
+		assert.Equal(t, RoomJoinedExpired, mr.TTL(key), key)
+	}
+	assert.False(t, mr.Exists(KeyRoomJoined("ch7")))
+}
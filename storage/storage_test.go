@@ -0,0 +1,312 @@
+package storage
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v7"
+	"github.com/stretchr/testify/assert"
+	"kingim"
+	"kingim/wire/pkt"
+)
+
+// testSessionStorage 所有SessionStorage实现都需要通过的测试，newStorage返回的函数用于让时间前进
+func testSessionStorage(t *testing.T, newStorage func(t *testing.T) (kingim.SessionStorage, func(time.Duration))) {
+	t.Run("GetLocations", func(t *testing.T) {
+		s, _ := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"}))
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch2", GateId: "gate2", Device: "pc"}))
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test2", ChannelId: "ch3", GateId: "gate1", Device: "phone"}))
+
+		locs, err := s.GetLocations("test1", "test2", "test3")
+		assert.Nil(t, err)
+		channels := make(map[string]string)
+		accounts := make(map[string]string)
+		for _, loc := range locs {
+			channels[loc.ChannelId] = loc.GateId
+			accounts[loc.ChannelId] = loc.Account
+		}
+		assert.Equal(t, map[string]string{"ch1": "gate1", "ch2": "gate2", "ch3": "gate1"}, channels)
+		assert.Equal(t, map[string]string{"ch1": "test1", "ch2": "test1", "ch3": "test2"}, accounts)
+
+		_, err = s.GetLocations("test3")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		s, _ := newStorage(t)
+		session := &pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone", App: "kim", Tags: []string{"a"}}
+		assert.Nil(t, s.Add(session))
+
+		got, err := s.Get("ch1")
+		assert.Nil(t, err)
+		assert.Equal(t, session.String(), got.String())
+
+		_, err = s.Get("ch2")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+	})
+
+	t.Run("GetLocation", func(t *testing.T) {
+		s, _ := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"}))
+		time.Sleep(time.Millisecond * 5)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch2", GateId: "gate2", Device: "pc"}))
+
+		loc, err := s.GetLocation("test1", "phone")
+		assert.Nil(t, err)
+		assert.Equal(t, "ch1", loc.ChannelId)
+		assert.Equal(t, "phone", loc.Device)
+
+		// 不指定设备时返回最近登录的设备
+		loc, err = s.GetLocation("test1", "")
+		assert.Nil(t, err)
+		assert.Equal(t, "ch2", loc.ChannelId)
+
+		_, err = s.GetLocation("test1", "pad")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+		_, err = s.GetLocation("test2", "")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		s, _ := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"}))
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch2", GateId: "gate1", Device: "pc"}))
+
+		assert.Nil(t, s.Delete("test1", "ch1"))
+		_, err := s.Get("ch1")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+		locs, err := s.GetLocations("test1")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(locs))
+		assert.Equal(t, "ch2", locs[0].ChannelId)
+
+		// 删除不存在的会话不报错
+		assert.Nil(t, s.Delete("test1", "ch1"))
+	})
+
+	t.Run("Relogin", func(t *testing.T) {
+		s, _ := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"}))
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch2", GateId: "gate2", Device: "phone"}))
+
+		// 旧连接的登出不能删掉同一设备上新的位置信息
+		assert.Nil(t, s.Delete("test1", "ch1"))
+		loc, err := s.GetLocation("test1", "phone")
+		assert.Nil(t, err)
+		assert.Equal(t, "ch2", loc.ChannelId)
+		assert.Equal(t, "gate2", loc.GateId)
+	})
+
+	t.Run("Expire", func(t *testing.T) {
+		s, advance := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"}))
+		advance(LocationExpired)
+
+		_, err := s.Get("ch1")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+		_, err = s.GetLocations("test1")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+		_, err = s.GetLocation("test1", "phone")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		s, advance := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"}))
+		advance(LocationExpired - time.Second)
+		assert.Nil(t, s.Refresh("gate1", "ch1"))
+
+		// 续期之后，超过最初的过期时间仍然有效
+		advance(LocationExpired - time.Second)
+		_, err := s.Get("ch1")
+		assert.Nil(t, err)
+		loc, err := s.GetLocation("test1", "phone")
+		assert.Nil(t, err)
+		assert.Equal(t, "ch1", loc.ChannelId)
+
+		advance(time.Second * 2)
+		_, err = s.Get("ch1")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+		_, err = s.GetLocations("test1")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+
+		assert.Equal(t, kingim.ErrSessionNil, s.Refresh("gate1", "ch2"))
+	})
+
+	t.Run("RefreshOtherGate", func(t *testing.T) {
+		s, advance := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"}))
+		advance(LocationExpired - time.Second)
+		// 其它网关不能为这个连接续期
+		assert.Equal(t, kingim.ErrSessionNil, s.Refresh("gate2", "ch1"))
+		advance(time.Second * 2)
+		_, err := s.Get("ch1")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+	})
+
+	t.Run("DeleteByGate", func(t *testing.T) {
+		s, _ := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"}))
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch2", GateId: "gate2", Device: "pc"}))
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test2", ChannelId: "ch3", GateId: "gate1", Device: "phone"}))
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test3", ChannelId: "ch4", GateId: "gate1", Device: "phone"}))
+		// 已经登出的会话不会被重复计算
+		assert.Nil(t, s.Delete("test3", "ch4"))
+
+		count, err := s.DeleteByGate("gate1")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+
+		_, err = s.Get("ch1")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+		_, err = s.Get("ch3")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+		locs, err := s.GetLocations("test1", "test2")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(locs))
+		assert.Equal(t, "gate2", locs[0].GateId)
+
+		count, err = s.DeleteByGate("gate1")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("GetByGate", func(t *testing.T) {
+		s, _ := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"}))
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch2", GateId: "gate2", Device: "pc"}))
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test2", ChannelId: "ch3", GateId: "gate1", Device: "phone"}))
+		assert.Nil(t, s.Delete("test2", "ch3"))
+
+		sessions, err := s.GetByGate("gate1")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(sessions))
+		assert.Equal(t, "ch1", sessions[0].ChannelId)
+
+		sessions, err = s.GetByGate("gate3")
+		assert.Nil(t, err)
+		assert.Empty(t, sessions)
+	})
+
+	t.Run("GetByTags", func(t *testing.T) {
+		s, advance := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{App: "app1", Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone", Tags: []string{"vip", "beta"}}))
+		assert.Nil(t, s.Add(&pkt.Session{App: "app1", Account: "test2", ChannelId: "ch2", GateId: "gate2", Device: "phone", Tags: []string{"beta"}}))
+		assert.Nil(t, s.Add(&pkt.Session{App: "app1", Account: "test3", ChannelId: "ch3", GateId: "gate1", Device: "phone"}))
+		assert.Nil(t, s.Add(&pkt.Session{App: "app2", Account: "test4", ChannelId: "ch4", GateId: "gate1", Device: "phone", Tags: []string{"vip"}}))
+		channels := func(sessions []*pkt.Session) []string {
+			var ids []string
+			for _, sn := range sessions {
+				ids = append(ids, sn.ChannelId)
+			}
+			return ids
+		}
+
+		sessions, err := s.GetByTags("app1", "vip", "beta")
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"ch1", "ch2"}, channels(sessions))
+		sessions, err = s.GetByTags("app1", "vip")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ch1"}, channels(sessions))
+
+		// 登出和过期的会话不再返回
+		assert.Nil(t, s.Delete("test1", "ch1"))
+		sessions, err = s.GetByTags("app1", "vip", "beta")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ch2"}, channels(sessions))
+		advance(LocationExpired)
+		sessions, err = s.GetByTags("app1", "beta")
+		assert.Nil(t, err)
+		assert.Empty(t, sessions)
+	})
+
+	t.Run("RefreshOtherDevice", func(t *testing.T) {
+		s, advance := newStorage(t)
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch1", GateId: "gate1", Device: "phone"}))
+		assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: "ch2", GateId: "gate1", Device: "pc"}))
+		advance(LocationExpired - time.Second)
+		assert.Nil(t, s.Refresh("gate1", "ch2"))
+
+		// pc的续期不能让没有续期的phone一直在线
+		advance(time.Second * 2)
+		locs, err := s.GetLocations("test1")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(locs))
+		assert.Equal(t, "ch2", locs[0].ChannelId)
+		_, err = s.GetLocation("test1", "phone")
+		assert.Equal(t, kingim.ErrSessionNil, err)
+		loc, err := s.GetLocation("test1", "")
+		assert.Nil(t, err)
+		assert.Equal(t, "ch2", loc.ChannelId)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		s, _ := newStorage(t)
+		devices := []string{"phone", "pc", "pad", "web"}
+		wg := sync.WaitGroup{}
+		for _, device := range devices {
+			wg.Add(1)
+			go func(device string) {
+				defer wg.Done()
+				channel := "ch_" + device
+				for i := 0; i < 20; i++ {
+					assert.Nil(t, s.Add(&pkt.Session{Account: "test1", ChannelId: channel, GateId: "gate1", Device: device}))
+					_, _ = s.GetLocations("test1")
+				}
+			}(device)
+		}
+		wg.Wait()
+		locs, err := s.GetLocations("test1")
+		assert.Nil(t, err)
+		assert.Equal(t, len(devices), len(locs))
+	})
+}
+
+func TestMemoryStorage(t *testing.T) {
+	testSessionStorage(t, func(t *testing.T) (kingim.SessionStorage, func(time.Duration)) {
+		s := newMemoryStorage(0)
+		var mu sync.Mutex
+		offset := time.Duration(0)
+		s.now = func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return time.Now().Add(offset)
+		}
+		return s, func(d time.Duration) {
+			mu.Lock()
+			offset += d
+			mu.Unlock()
+		}
+	})
+}
+
+func TestRedisStorage(t *testing.T) {
+	testSessionStorage(t, func(t *testing.T) (kingim.SessionStorage, func(time.Duration)) {
+		mr, err := miniredis.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(mr.Close)
+		cli := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() {
+			_ = cli.Close()
+		})
+		s := newRedisStorage(cli, 0)
+		var mu sync.Mutex
+		offset := time.Duration(0)
+		s.now = func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return time.Now().Add(offset)
+		}
+		return s, func(d time.Duration) {
+			mu.Lock()
+			offset += d
+			mu.Unlock()
+			mr.FastForward(d)
+		}
+	})
+}
@@ -62,6 +62,21 @@ func (mr *MockSessionStorageMockRecorder) Delete(account, channelId interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSessionStorage)(nil).Delete), account, channelId)
 }
 
+// DeleteByGate mocks base method.
+func (m *MockSessionStorage) DeleteByGate(gateId string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByGate", gateId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByGate indicates an expected call of DeleteByGate.
+func (mr *MockSessionStorageMockRecorder) DeleteByGate(gateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByGate", reflect.TypeOf((*MockSessionStorage)(nil).DeleteByGate), gateId)
+}
+
 // Get mocks base method.
 func (m *MockSessionStorage) Get(channelId string) (*pkt.Session, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +92,41 @@ func (mr *MockSessionStorageMockRecorder) Get(channelId interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSessionStorage)(nil).Get), channelId)
 }
 
+// GetByGate mocks base method.
+func (m *MockSessionStorage) GetByGate(gateId string) ([]*pkt.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGate", gateId)
+	ret0, _ := ret[0].([]*pkt.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByGate indicates an expected call of GetByGate.
+func (mr *MockSessionStorageMockRecorder) GetByGate(gateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGate", reflect.TypeOf((*MockSessionStorage)(nil).GetByGate), gateId)
+}
+
+// GetByTags mocks base method.
+func (m *MockSessionStorage) GetByTags(app string, tags ...string) ([]*pkt.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{app}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetByTags", varargs...)
+	ret0, _ := ret[0].([]*pkt.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTags indicates an expected call of GetByTags.
+func (mr *MockSessionStorageMockRecorder) GetByTags(app interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{app}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTags", reflect.TypeOf((*MockSessionStorage)(nil).GetByTags), varargs...)
+}
+
 // GetLocation mocks base method.
 func (m *MockSessionStorage) GetLocation(account, device string) (*Location, error) {
 	m.ctrl.T.Helper()
@@ -110,3 +160,17 @@ func (mr *MockSessionStorageMockRecorder) GetLocations(account ...interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocations", reflect.TypeOf((*MockSessionStorage)(nil).GetLocations), account...)
 }
+
+// Refresh mocks base method.
+func (m *MockSessionStorage) Refresh(gateId, channelId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", gateId, channelId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockSessionStorageMockRecorder) Refresh(gateId, channelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockSessionStorage)(nil).Refresh), gateId, channelId)
+}
@@ -0,0 +1,244 @@
+package kingim
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sync/atomic"
+)
+
+// DispatchMode 上行消息的处理模式
+type DispatchMode string
+
+const (
+	// DispatchModeGoroutine 每条消息一个协程，不保证顺序
+	DispatchModeGoroutine DispatchMode = "goroutine"
+	// DispatchModeOrdered 同一个key(默认是channelID)的消息按接收的顺序依次处理
+	DispatchModeOrdered DispatchMode = "ordered"
+	// DispatchModePool 固定数量的协程共享一个队列，不保证顺序
+	DispatchModePool DispatchMode = "pool"
+)
+
+// DispatchStrategy 决定Readloop读取到的消息如何交给MessageListener处理
+type DispatchStrategy interface {
+	// Dispatch 提交一条消息，队列满了之后会阻塞，从而对读取端形成背压
+	Dispatch(ag Agent, payload []byte, lst MessageListener)
+	// Len 队列中等待处理的消息数，goroutine模式下是正在处理的消息数
+	Len() int
+	// Close 停止处理，之后提交的消息会被丢弃
+	Close()
+}
+
+// DispatchOptions DispatchStrategy 的配置
+type DispatchOptions struct {
+	Mode DispatchMode
+	// Workers ordered模式下是队列的分片数，pool模式下是协程数，goroutine模式下是同时处理的最大消息数(0表示不限制)
+	Workers int
+	// QueueSize 每个队列的长度
+	QueueSize int
+	// OrderKey 计算消息的排序key，只在ordered模式下使用，默认使用Agent.ID()
+	OrderKey func(ag Agent, payload []byte) string
+}
+
+// NewDispatchStrategy 根据配置创建一个DispatchStrategy
+func NewDispatchStrategy(opts DispatchOptions) (DispatchStrategy, error) {
+	if opts.Workers < 0 || opts.QueueSize < 0 {
+		return nil, fmt.Errorf("invalid dispatch options %v", opts)
+	}
+	if opts.QueueSize == 0 {
+		opts.QueueSize = DefaultDispatchQueueSize
+	}
+	switch opts.Mode {
+	case DispatchModeGoroutine:
+		return newGoroutineStrategy(opts.Workers), nil
+	case DispatchModeOrdered, "":
+		if opts.Workers == 0 {
+			opts.Workers = DefaultDispatchWorkers
+		}
+		return newOrderedStrategy(opts.Workers, opts.QueueSize, opts.OrderKey), nil
+	case DispatchModePool:
+		if opts.Workers == 0 {
+			opts.Workers = DefaultDispatchWorkers
+		}
+		return newPoolStrategy(opts.Workers, opts.QueueSize), nil
+	}
+	return nil, fmt.Errorf("unknown dispatch mode %s", opts.Mode)
+}
+
+type strategyListener struct {
+	strategy DispatchStrategy
+	lst      MessageListener
+}
+
+// NewStrategyListener 返回一个MessageListener，把收到的消息交给strategy处理
+func NewStrategyListener(strategy DispatchStrategy, lst MessageListener) MessageListener {
+	return &strategyListener{
+		strategy: strategy,
+		lst:      lst,
+	}
+}
+
+func (l *strategyListener) Receive(ag Agent, payload []byte) {
+	l.strategy.Dispatch(ag, payload, l.lst)
+}
+
+type task struct {
+	ag      Agent
+	payload []byte
+	lst     MessageListener
+}
+
+func (t *task) run() {
+	t.lst.Receive(t.ag, t.payload)
+}
+
+// goroutineStrategy 每条消息一个协程，通过信号量限制同时处理的消息数
+type goroutineStrategy struct {
+	sem      chan struct{}
+	inflight int32
+	closed   *Event
+}
+
+func newGoroutineStrategy(max int) *goroutineStrategy {
+	s := &goroutineStrategy{
+		closed: NewEvent(),
+	}
+	if max > 0 {
+		s.sem = make(chan struct{}, max)
+	}
+	return s
+}
+
+func (s *goroutineStrategy) Dispatch(ag Agent, payload []byte, lst MessageListener) {
+	if s.sem != nil {
+		select {
+		case s.sem <- struct{}{}:
+		case <-s.closed.Done():
+			return
+		}
+	}
+	atomic.AddInt32(&s.inflight, 1)
+	messageQueueDepth.WithLabelValues(string(DispatchModeGoroutine)).Inc()
+	go func() {
+		defer func() {
+			atomic.AddInt32(&s.inflight, -1)
+			messageQueueDepth.WithLabelValues(string(DispatchModeGoroutine)).Dec()
+			if s.sem != nil {
+				<-s.sem
+			}
+		}()
+		lst.Receive(ag, payload)
+	}()
+}
+
+func (s *goroutineStrategy) Len() int {
+	return int(atomic.LoadInt32(&s.inflight))
+}
+
+func (s *goroutineStrategy) Close() {
+	s.closed.Fire()
+}
+
+// worker 从自己的队列中依次取出消息处理
+type worker struct {
+	queue  chan *task
+	mode   DispatchMode
+	closed *Event
+}
+
+func (w *worker) push(t *task) {
+	select {
+	case w.queue <- t:
+		messageQueueDepth.WithLabelValues(string(w.mode)).Inc()
+	case <-w.closed.Done():
+	}
+}
+
+func (w *worker) loop() {
+	for {
+		select {
+		case t := <-w.queue:
+			messageQueueDepth.WithLabelValues(string(w.mode)).Dec()
+			t.run()
+		case <-w.closed.Done():
+			return
+		}
+	}
+}
+
+// orderedStrategy 按key把消息分配到固定的队列中，每个队列由一个协程顺序处理，
+// 因此同一个key的消息一定按接收的顺序处理
+type orderedStrategy struct {
+	workers []*worker
+	key     func(ag Agent, payload []byte) string
+	closed  *Event
+}
+
+func newOrderedStrategy(shards, queueSize int, key func(ag Agent, payload []byte) string) *orderedStrategy {
+	s := &orderedStrategy{
+		workers: make([]*worker, shards),
+		key:     key,
+		closed:  NewEvent(),
+	}
+	if s.key == nil {
+		s.key = func(ag Agent, _ []byte) string {
+			return ag.ID()
+		}
+	}
+	for i := range s.workers {
+		s.workers[i] = &worker{
+			queue:  make(chan *task, queueSize),
+			mode:   DispatchModeOrdered,
+			closed: s.closed,
+		}
+		go s.workers[i].loop()
+	}
+	return s
+}
+
+func (s *orderedStrategy) Dispatch(ag Agent, payload []byte, lst MessageListener) {
+	code := crc32.ChecksumIEEE([]byte(s.key(ag, payload)))
+	s.workers[code%uint32(len(s.workers))].push(&task{ag: ag, payload: payload, lst: lst})
+}
+
+func (s *orderedStrategy) Len() int {
+	l := 0
+	for _, w := range s.workers {
+		l += len(w.queue)
+	}
+	return l
+}
+
+func (s *orderedStrategy) Close() {
+	s.closed.Fire()
+}
+
+// poolStrategy 固定数量的协程共享一个队列
+type poolStrategy struct {
+	worker *worker
+}
+
+func newPoolStrategy(workers, queueSize int) *poolStrategy {
+	s := &poolStrategy{
+		worker: &worker{
+			queue:  make(chan *task, queueSize),
+			mode:   DispatchModePool,
+			closed: NewEvent(),
+		},
+	}
+	for i := 0; i < workers; i++ {
+		go s.worker.loop()
+	}
+	return s
+}
+
+func (s *poolStrategy) Dispatch(ag Agent, payload []byte, lst MessageListener) {
+	s.worker.push(&task{ag: ag, payload: payload, lst: lst})
+}
+
+func (s *poolStrategy) Len() int {
+	return len(s.worker.queue)
+}
+
+func (s *poolStrategy) Close() {
+	s.worker.closed.Fire()
+}
@@ -0,0 +1,114 @@
+package kingim
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAgent struct {
+	id string
+}
+
+func (a *testAgent) ID() string {
+	return a.id
+}
+
+func (a *testAgent) Push([]byte) error {
+	return nil
+}
+
+type recordListener struct {
+	sync.Mutex
+	received map[string][]string
+	wg       *sync.WaitGroup
+}
+
+func (l *recordListener) Receive(ag Agent, payload []byte) {
+	l.Lock()
+	l.received[ag.ID()] = append(l.received[ag.ID()], string(payload))
+	l.Unlock()
+	l.wg.Done()
+}
+
+func TestOrderedStrategy(t *testing.T) {
+	strategy, err := NewDispatchStrategy(DispatchOptions{Mode: DispatchModeOrdered, Workers: 4, QueueSize: 8})
+	assert.Nil(t, err)
+	defer strategy.Close()
+
+	const agents, count = 10, 100
+	wg := &sync.WaitGroup{}
+	wg.Add(agents * count)
+	lst := &recordListener{received: make(map[string][]string), wg: wg}
+	for i := 0; i < agents; i++ {
+		go func(ag Agent) {
+			for j := 0; j < count; j++ {
+				strategy.Dispatch(ag, []byte(fmt.Sprint(j)), lst)
+			}
+		}(&testAgent{id: fmt.Sprintf("ch%d", i)})
+	}
+	wg.Wait()
+
+	for id, list := range lst.received {
+		assert.Equal(t, count, len(list), id)
+		for j, payload := range list {
+			assert.Equal(t, fmt.Sprint(j), payload, id)
+		}
+	}
+}
+
+type blockListener struct {
+	release chan struct{}
+}
+
+func (l *blockListener) Receive(Agent, []byte) {
+	<-l.release
+}
+
+func TestPoolStrategy_Backpressure(t *testing.T) {
+	strategy, err := NewDispatchStrategy(DispatchOptions{Mode: DispatchModePool, Workers: 1, QueueSize: 2})
+	assert.Nil(t, err)
+	defer strategy.Close()
+
+	lst := &blockListener{release: make(chan struct{})}
+	ag := &testAgent{id: "ch1"}
+	// 一条正在处理，两条在队列中
+	for i := 0; i < 3; i++ {
+		strategy.Dispatch(ag, nil, lst)
+	}
+	assert.Eventually(t, func() bool {
+		return strategy.Len() == 2
+	}, time.Second, time.Millisecond*10)
+
+	blocked := make(chan struct{})
+	go func() {
+		strategy.Dispatch(ag, nil, lst)
+		close(blocked)
+	}()
+	select {
+	case <-blocked:
+		t.Fatal("dispatch should block when the queue is full")
+	case <-time.After(time.Millisecond * 50):
+	}
+	close(lst.release)
+	<-blocked
+}
+
+func TestNewDispatchStrategy(t *testing.T) {
+	_, err := NewDispatchStrategy(DispatchOptions{Mode: "unknown"})
+	assert.NotNil(t, err)
+
+	strategy, err := NewDispatchStrategy(DispatchOptions{Mode: DispatchModeGoroutine, Workers: 2})
+	assert.Nil(t, err)
+	wg := &sync.WaitGroup{}
+	wg.Add(3)
+	lst := &recordListener{received: make(map[string][]string), wg: wg}
+	for i := 0; i < 3; i++ {
+		strategy.Dispatch(&testAgent{id: "ch1"}, []byte("hello"), lst)
+	}
+	wg.Wait()
+	assert.Equal(t, 3, len(lst.received["ch1"]))
+}
@@ -24,6 +24,8 @@ type Server struct {
 	kingim.Acceptor
 	kingim.MessageListener
 	kingim.StateListener
+	strategy kingim.DispatchStrategy
+	channelOptions kingim.ChannelOptions
 	once sync.Once
 	options ServerOptions
 	quit *kingim.Event
@@ -57,6 +59,10 @@ func (s *Server) Start() error {
 	if s.Acceptor == nil {
 		s.Acceptor = new(defaultAcceptor)
 	}
+	if s.strategy == nil {
+		s.strategy, _ = kingim.NewDispatchStrategy(kingim.DispatchOptions{Mode: kingim.DispatchModeOrdered})
+	}
+	listener := kingim.NewStrategyListener(s.strategy, s.MessageListener)
 	lst,err := net.Listen("tcp", s.listen)
 	if err != nil {
 		return err
@@ -84,12 +90,12 @@ func (s *Server) Start() error {
 				_  = conn.WriteFrame(kingim.OpClose, []byte(err.Error()))
 				conn.Close()
 			}
-			channel := kingim.NewChannel(id, conn)
+			channel := kingim.NewChannelWithOptions(id, conn, s.channelOptions)
 			channel.SetWriteWait(s.options.writewait)
 			channel.SetReadWait(s.options.readwait)
 			s.Add(channel)
 			log.Info("accept" ,channel)
-			err = channel.Readloop(s.MessageListener)
+			err = channel.Readloop(listener)
 			if err!= nil {
 				log.Info(err)
 			}
@@ -114,6 +120,9 @@ func (s * Server) Shutdown (ctx context.Context) error {
 		defer func() {
 			log.Info("shutdown")
 		}()
+		if s.strategy != nil {
+			s.strategy.Close()
+		}
 		channels := s.All()
 		for _, ch := range channels {
 			ch.Close()
@@ -151,6 +160,14 @@ func (s*Server) SetChannelMap (channels kingim.ChannelMap) {
 	s.ChannelMap = channels
 }
 
+func (s*Server) SetDispatchStrategy(strategy kingim.DispatchStrategy) {
+	s.strategy = strategy
+}
+
+func (s*Server) SetChannelOptions(opts kingim.ChannelOptions) {
+	s.channelOptions = opts
+}
+
 func (s*Server) Push(id string,data []byte) error {
 	ch, ok := s.ChannelMap.Get(id)
 	if !ok {
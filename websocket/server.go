@@ -26,6 +26,8 @@ type Server struct {
 	kingim.Acceptor
 	kingim.MessageListener
 	kingim.StateListener
+	strategy kingim.DispatchStrategy
+	channelOptions kingim.ChannelOptions
 	once sync.Once
 	options ServerOptions
 }
@@ -63,6 +65,10 @@ func (s*Server) Start() error {
 	if s.ChannelMap == nil {
 		s.ChannelMap = kingim.NewChannels(100)
 	}
+	if s.strategy == nil {
+		s.strategy, _ = kingim.NewDispatchStrategy(kingim.DispatchOptions{Mode: kingim.DispatchModeOrdered})
+	}
+	listener := kingim.NewStrategyListener(s.strategy, s.MessageListener)
 	mux.HandleFunc("/", func (w http.ResponseWriter, r*http.Request) {
 		rawconn,_,_,err := ws.UpgradeHTTP(r,w)
 		if err != nil {
@@ -84,12 +90,12 @@ func (s*Server) Start() error {
 			conn.Close()
 			return
 		}
-		channel := kingim.NewChannel(id, conn)
+		channel := kingim.NewChannelWithOptions(id, conn, s.channelOptions)
 		channel.SetReadWait(s.options.readwait)
 		channel.SetWriteWait(s.options.writewait)
 		s.Add(channel)
 		go func(ch kingim.Channel) {
-			err := ch.Readloop(s.MessageListener)
+			err := ch.Readloop(listener)
 			if err != nil {
 				log.Info(err)
 			}
@@ -120,13 +126,20 @@ func (s*Server) SetReadWait(readWait time.Duration) {
 func (s*Server) SetChannelMap(channels kingim.ChannelMap) {
 	s.ChannelMap =channels
 }
+
+func (s*Server) SetDispatchStrategy(strategy kingim.DispatchStrategy) {
+	s.strategy = strategy
+}
+
+func (s*Server) SetChannelOptions(opts kingim.ChannelOptions) {
+	s.channelOptions = opts
+}
 func (s*Server) Push(id string, data []byte) error {
 	ch,ok := s.ChannelMap.Get(id)
 	if !ok {
 		return errors.New("channel not found")
 	}
-	ch.Push(data)
-	return nil
+	return ch.Push(data)
 }
 
 func (s*Server) Shutdown(ctx context.Context) error {
@@ -138,6 +151,9 @@ func (s*Server) Shutdown(ctx context.Context) error {
 		defer func() {
 			log.Info("shutdown")
 		}()
+		if s.strategy != nil {
+			s.strategy.Close()
+		}
 		channels := s.ChannelMap.All()
 		if channels != nil {
 			for _,ch := range channels{
@@ -12,22 +12,77 @@ const (
 	// login
 	CommandLoginSignIn  = "login.signin"
 	CommandLoginSignOut = "login.signout"
+	CommandLoginRefresh = "login.refresh"
 
 	// chat
 	CommandChatUserTalk  = "chat.user.talk"
 	CommandChatGroupTalk = "chat.group.talk"
 	CommandChatTalkAck   = "chat.talk.ack"
+	// 服务端推送给发送方的送达回执
+	CommandChatTalkDelivered = "chat.talk.delivered"
+	// 撤回
+	CommandChatMessageRecall = "chat.message.recall"
+	// 修改
+	CommandChatMessageEdit = "chat.message.edit"
+	// 已读
+	CommandChatRead = "chat.read"
+	// 正在输入等不保存的信号
+	CommandChatSignal = "chat.signal"
+
+	// 会话列表
+	CommandConversationList   = "chat.conversation.list"
+	CommandConversationUpdate = "chat.conversation.update"
+
+	// 历史消息
+	CommandChatHistory       = "chat.history"
+	CommandChatMessageSearch = "chat.message.search"
 
 	// 离线
 	CommandOfflineIndex   = "chat.offline.index"
 	CommandOfflineContent = "chat.offline.content"
 
+	// 在线状态
+	CommandPresenceSubscribe = "presence.subscribe"
+	CommandPresenceQuery     = "presence.query"
+	CommandPresenceStatus    = "presence.status"
+	// 服务端推送给订阅者的状态变化
+	CommandPresenceNotify = "presence.notify"
+
+	// 好友关系
+	CommandContactRequest  = "contact.request"
+	CommandContactHandle   = "contact.request.handle"
+	CommandContactRequests = "contact.requests"
+	CommandContactList     = "contact.list"
+	CommandContactRemove   = "contact.remove"
+	CommandContactBlock    = "contact.block"
+	CommandContactRemark   = "contact.remark"
+
 	// 群管理
-	CommandGroupCreate  = "chat.group.create"
-	CommandGroupJoin    = "chat.group.join"
-	CommandGroupQuit    = "chat.group.quit"
-	CommandGroupMembers = "chat.group.members"
-	CommandGroupDetail  = "chat.group.detail"
+	CommandGroupCreate   = "chat.group.create"
+	CommandGroupJoin     = "chat.group.join"
+	CommandGroupQuit     = "chat.group.quit"
+	CommandGroupMembers  = "chat.group.members"
+	CommandGroupDetail   = "chat.group.detail"
+	CommandGroupKick     = "chat.group.kick"
+	CommandGroupRole     = "chat.group.role"
+	CommandGroupTransfer = "chat.group.transfer"
+	CommandGroupJoinMode = "chat.group.joinmode"
+	CommandGroupDissolve = "chat.group.dissolve"
+	// 禁言和公告
+	CommandGroupMute         = "chat.group.mute"
+	CommandGroupMemberMute   = "chat.group.member.mute"
+	CommandGroupAnnouncement = "chat.group.announcement"
+	// 入群审批
+	CommandGroupJoinRequests = "chat.group.join.requests"
+	CommandGroupJoinHandle   = "chat.group.join.handle"
+
+	// 房间
+	CommandRoomJoin  = "room.join"
+	CommandRoomLeave = "room.leave"
+	CommandRoomTalk  = "room.talk"
+
+	// 后台服务通过推送接口发送的通知
+	CommandPushNotify = "push.notify"
 )
 
 // Meta Key of a packet
@@ -36,6 +91,12 @@ const (
 	MetaDestServer = "dest.server"
 	// 消息将要送达的channels
 	MetaDestChannels = "dest.channels"
+	// 房间消息只推送一次到网关，由网关推送给本地加入了这个房间的channels
+	MetaDestRoom = "dest.room"
+	// 广播消息只推送一次到网关，由网关推送给本地这个app所有的channels
+	MetaDestBroadcast = "dest.broadcast"
+	// 房间消息的发送方，网关推送时跳过这个channel
+	MetaSkipChannel = "skip.channel"
 )
 
 // Protocol Protocol
@@ -51,11 +112,18 @@ const (
 const (
 	SNWGateway = "wgateway"
 	SNTGateway = "tgateway"
-	SNLogin    = "chat"  //login
+	SNLogin    = "login" //login
 	SNChat     = "chat"  //chat
 	SNService  = "royal" //rpc service
 )
 
+// CommandServices 指令前缀与处理它的服务名不同时的映射
+var CommandServices = map[string]string{
+	"presence": SNLogin,
+	"contact":  SNChat,
+	"room":     SNChat,
+}
+
 // ServiceID ServiceID
 type ServiceID string
 
@@ -75,6 +143,23 @@ const (
 	OfflineSyncIndexCount     = 2000                //单次同步消息索引的数量
 	OfflineMessageExpiresIn   = 15                  // 离线消息过期时间
 	MessageMaxCountPerPage    = 200                 // 同步消息内容时每页的最大数据
+	ConversationMaxPerPage    = 100                 // 会话列表每页的最大数量
+	ConversationPreviewLength = 60                  // 会话中最后一条消息预览的最大字符数
+	GroupAnnouncementLength   = 1000                // 群公告的最大字符数
+)
+
+// 群成员的角色
+const (
+	GroupRoleMember = 0
+	GroupRoleAdmin  = 1
+	GroupRoleOwner  = 2
+)
+
+// 加入群的方式
+const (
+	GroupJoinOpen       = 0 // 自由加入
+	GroupJoinApproval   = 1 // 需要群主或者管理员审批
+	GroupJoinInviteOnly = 2 // 只能由群主或者管理员邀请
 )
 
 const (
@@ -136,6 +136,9 @@ func (h *Header) ServiceName() string {
 	if len(arr) <= 1 {
 		return "default"
 	}
+	if name, ok := wire.CommandServices[arr[0]]; ok {
+		return name
+	}
 	return arr[0]
 }
 
@@ -73,6 +73,14 @@ func TestReadPkt(t *testing.T) {
 	assert.Equal(t, 1, len(packet.Meta))
 }
 
+func TestServiceName(t *testing.T) {
+	assert.Equal(t, "auth", New("auth.login.aa").ServiceName())
+	// 状态的查询和订阅由登录服务处理
+	assert.Equal(t, wire.SNLogin, New(wire.CommandPresenceQuery).ServiceName())
+	assert.Equal(t, wire.SNLogin, New(wire.CommandPresenceSubscribe).ServiceName())
+	assert.Equal(t, wire.SNChat, New(wire.CommandChatUserTalk).ServiceName())
+}
+
 func Test_Encode(t *testing.T) {
 	var pkt = struct {
 		Source   uint32
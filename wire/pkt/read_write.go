@@ -6,7 +6,9 @@ import (
 	"io"
 	"reflect"
 
+	"google.golang.org/protobuf/encoding/protowire"
 	"kingim/wire"
+	"kingim/wire/endian"
 )
 
 type Packet interface {
@@ -60,6 +62,39 @@ func Read(r io.Reader) (interface{}, error) {
 	}
 }
 
+// ReadChannelId 只解析LogicPkt的header中的channelId，不反序列化整个消息
+func ReadChannelId(payload []byte) (string, error) {
+	if len(payload) < 8 || !bytes.Equal(payload[:4], wire.MagicLogicPkt[:]) {
+		return "", fmt.Errorf("packet is not a logic packet")
+	}
+	size := int(endian.Default.Uint32(payload[4:8]))
+	if len(payload) < 8+size {
+		return "", io.ErrUnexpectedEOF
+	}
+	header := payload[8 : 8+size]
+	for len(header) > 0 {
+		num, typ, n := protowire.ConsumeTag(header)
+		if n < 0 {
+			return "", protowire.ParseError(n)
+		}
+		header = header[n:]
+		// channelId 是Header的第2个字段
+		if num == 2 && typ == protowire.BytesType {
+			val, n := protowire.ConsumeBytes(header)
+			if n < 0 {
+				return "", protowire.ParseError(n)
+			}
+			return string(val), nil
+		}
+		n = protowire.ConsumeFieldValue(num, typ, header)
+		if n < 0 {
+			return "", protowire.ParseError(n)
+		}
+		header = header[n:]
+	}
+	return "", nil
+}
+
 func Marshal(p Packet) []byte {
 	buf := new(bytes.Buffer)
 	kind := reflect.TypeOf(p).Elem()
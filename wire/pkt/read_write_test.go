@@ -25,3 +25,19 @@ func TestMarshal(t *testing.T) {
 	assert.Equal(t, wire.MagicLogicPkt[1], bts2[1])
 	assert.Equal(t, wire.MagicLogicPkt[2], bts2[2])
 }
+
+func TestReadChannelId(t *testing.T) {
+	lp := New("chat.user.talk", WithChannel("channel1"), WithSeq(10), WithDest("test2"))
+	lp.AddStringMeta(wire.MetaDestServer, "gateway1")
+	lp.WriteBody(&MessageReq{Body: "hello"})
+	id, err := ReadChannelId(Marshal(lp))
+	assert.Nil(t, err)
+	assert.Equal(t, "channel1", id)
+
+	id, err = ReadChannelId(Marshal(New("chat.user.talk")))
+	assert.Nil(t, err)
+	assert.Equal(t, "", id)
+
+	_, err = ReadChannelId(Marshal(&BasicPkt{Code: CodePing}))
+	assert.NotNil(t, err)
+}